@@ -0,0 +1,545 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/crunk1/xfuego/internal/field"
+	"github.com/crunk1/xfuego/internal/paramspopulator"
+)
+
+// basicTypes maps the supported param type names to their types.
+var basicTypes = map[string]reflect.Type{
+	"bool":    reflect.TypeFor[bool](),
+	"string":  reflect.TypeFor[string](),
+	"int":     reflect.TypeFor[int](),
+	"int8":    reflect.TypeFor[int8](),
+	"int16":   reflect.TypeFor[int16](),
+	"int32":   reflect.TypeFor[int32](),
+	"int64":   reflect.TypeFor[int64](),
+	"uint":    reflect.TypeFor[uint](),
+	"uint8":   reflect.TypeFor[uint8](),
+	"uint16":  reflect.TypeFor[uint16](),
+	"uint32":  reflect.TypeFor[uint32](),
+	"uint64":  reflect.TypeFor[uint64](),
+	"float32": reflect.TypeFor[float32](),
+	"float64": reflect.TypeFor[float64](),
+	"byte":    reflect.TypeFor[byte](),
+	"rune":    reflect.TypeFor[rune](),
+}
+
+// paramsStruct is a params struct to generate the populate function and params of.
+type paramsStruct struct {
+	Name        string
+	Params      []field.Param
+	Fingerprint string
+}
+
+// generate returns the source of the file declaring and registering the populate functions and params of the
+// typeNames structs of a package, and registering the field doc comments of its docTypeNames structs.
+func generate(pkgName string, files []*ast.File, typeNames []string, docTypeNames []string) ([]byte, error) {
+	var structs []paramsStruct
+	for _, typeName := range typeNames {
+		st, err := findStruct(files, typeName)
+		if err != nil {
+			return nil, err
+		}
+		s, err := parseParamsStruct(typeName, st)
+		if err != nil {
+			return nil, err
+		}
+		structs = append(structs, s)
+	}
+	docs := make([]map[string]string, len(docTypeNames))
+	for i, typeName := range docTypeNames {
+		st, err := findStruct(files, typeName)
+		if err != nil {
+			return nil, err
+		}
+		if docs[i], err = fieldDocs(typeName, st); err != nil {
+			return nil, err
+		}
+	}
+
+	g := &generator{imports: map[string]bool{}}
+	for _, s := range structs {
+		g.populateFunc(s)
+		g.paramsVar(s)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by xfuego-gen. DO NOT EDIT.\n\n")
+	b.WriteString("package " + pkgName + "\n\n")
+	b.WriteString("import (\n")
+	for _, path := range []string{"reflect", "regexp", "unicode/utf8"} {
+		if g.imports[path] {
+			b.WriteString(strconv.Quote(path) + "\n")
+		}
+	}
+	b.WriteString("\n\"github.com/crunk1/xfuego/paramsgen\"\n)\n\n")
+	if g.vars.Len() > 0 {
+		b.WriteString("var (\n")
+		b.Write(g.vars.Bytes())
+		b.WriteString(")\n\n")
+	}
+	b.WriteString("func init() {\n")
+	for _, s := range structs {
+		fingerprint := strconv.Quote(s.Fingerprint)
+		if strconv.CanBackquote(s.Fingerprint) {
+			fingerprint = "`" + s.Fingerprint + "`"
+		}
+		fmt.Fprintf(&b, "paramsgen.Register(%s, %s, %s)\n", funcName(s.Name), paramsVarName(s.Name), fingerprint)
+	}
+	for i, typeName := range docTypeNames {
+		fmt.Fprintf(&b, "paramsgen.RegisterDocs[%s](map[string]string{\n", typeName)
+		for _, fieldName := range slices.Sorted(maps.Keys(docs[i])) {
+			fmt.Fprintf(&b, "%q: %s,\n", fieldName, strconv.Quote(docs[i][fieldName]))
+		}
+		b.WriteString("})\n")
+	}
+	b.WriteString("}\n")
+	b.Write(g.funcs.Bytes())
+	return format.Source(b.Bytes())
+}
+
+// findStruct returns the declaration of the typeName struct.
+func findStruct(files []*ast.File, typeName string) (*ast.StructType, error) {
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec, ok := spec.(*ast.TypeSpec)
+				if !ok || spec.Name.Name != typeName {
+					continue
+				}
+				st, ok := spec.Type.(*ast.StructType)
+				if !ok || spec.TypeParams != nil {
+					return nil, fmt.Errorf("type %s is not a non-generic struct", typeName)
+				}
+				return st, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("type %s not found", typeName)
+}
+
+// parseParamsStruct parses the param fields of a struct, as xfuego does with reflection.
+func parseParamsStruct(typeName string, st *ast.StructType) (s paramsStruct, err error) {
+	s.Name = typeName
+	var fields []reflect.StructField
+	for _, f := range st.Fields.List {
+		tag, err := fieldTag(typeName, f)
+		if err != nil {
+			return s, err
+		}
+		if len(f.Names) == 0 {
+			return s, fmt.Errorf("%s: embedded fields are not supported", typeName)
+		}
+		t, supported := fieldType(f.Type)
+		for _, name := range f.Names {
+			sf := reflect.StructField{Name: name.Name, Type: t, Tag: tag}
+			if !name.IsExported() {
+				sf.PkgPath = "main"
+			}
+			if !supported {
+				if hasParamTag(tag) {
+					return s, fmt.Errorf("%s: param field type is not supported, leave the struct out of -type to populate it with reflection: field=%s", typeName, name.Name)
+				}
+				continue
+			}
+			p, err := parseField(sf)
+			if err != nil {
+				return s, fmt.Errorf("%s: %w", typeName, err)
+			}
+			if p.In == field.InNone {
+				continue
+			}
+			if p.Aliases != nil {
+				return s, fmt.Errorf("%s: param opt 'alias' is not supported, leave the struct out of -type to populate it with reflection: field=%s", typeName, name.Name)
+			}
+			s.Params = append(s.Params, p)
+			fields = append(fields, sf)
+		}
+	}
+	if err := checkParams(fields); err != nil {
+		return s, fmt.Errorf("%s: %w", typeName, err)
+	}
+	s.Fingerprint = paramspopulator.Fingerprint(fields)
+	return s, nil
+}
+
+// checkParams checks the params of the param fields together, e.g. for duplicate names, as xfuego does at route
+// registration when parsing the struct.
+func checkParams(fields []reflect.StructField) error {
+	var structErr *field.StructError
+	if _, err := field.TryParseStruct(reflect.StructOf(fields)); errors.As(err, &structErr) {
+		return errors.New(strings.Join(structErr.Errors, "; "))
+	}
+	return nil
+}
+
+// fieldDocs returns the doc comments of the param fields of a struct, keyed by field name. Embedded fields are
+// skipped: their docs are those of the fields of the embedded struct.
+func fieldDocs(typeName string, st *ast.StructType) (map[string]string, error) {
+	docs := map[string]string{}
+	for _, f := range st.Fields.List {
+		tag, err := fieldTag(typeName, f)
+		if err != nil {
+			return nil, err
+		}
+		doc := strings.TrimSpace(f.Doc.Text())
+		if doc == "" || !hasParamTag(tag) {
+			continue
+		}
+		for _, name := range f.Names {
+			docs[name.Name] = doc
+		}
+	}
+	return docs, nil
+}
+
+// fieldTag returns the tag of a struct field.
+func fieldTag(typeName string, f *ast.Field) (reflect.StructTag, error) {
+	if f.Tag == nil {
+		return "", nil
+	}
+	tagValue, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return "", fmt.Errorf("%s: invalid tag %s", typeName, f.Tag.Value)
+	}
+	return reflect.StructTag(tagValue), nil
+}
+
+func hasParamTag(tag reflect.StructTag) bool {
+	for _, key := range []string{"query", "path", "header", "cookie", "form"} {
+		if _, ok := tag.Lookup(key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldType returns the type of a bool, string or number field, a pointer to one, or a slice of one.
+func fieldType(expr ast.Expr) (reflect.Type, bool) {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		t, ok := basicTypes[expr.Name]
+		return t, ok
+	case *ast.StarExpr:
+		if t, ok := fieldType(expr.X); ok && t.Kind() != reflect.Pointer {
+			return reflect.PointerTo(t), true
+		}
+	case *ast.ArrayType:
+		if t, ok := fieldType(expr.Elt); ok && expr.Len == nil && t.Kind() != reflect.Pointer && t.Kind() != reflect.Slice {
+			return reflect.SliceOf(t), true
+		}
+	}
+	return nil, false
+}
+
+// parseField is field.Parse, returning its panics as errors.
+func parseField(sf reflect.StructField) (p field.Param, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return field.Parse(sf), nil
+}
+
+// generator writes the populate functions and the package-level variables they use.
+type generator struct {
+	imports map[string]bool
+	vars    bytes.Buffer
+	funcs   bytes.Buffer
+}
+
+// populateFunc writes the populate function of a params struct.
+func (g *generator) populateFunc(s paramsStruct) {
+	w := &g.funcs
+	fmt.Fprintf(w, "\n// %s populates a %s from the request, see paramsgen.\n", funcName(s.Name), s.Name)
+	fmt.Fprintf(w, "func %s(c paramsgen.Getters, params *%s) error {\n", funcName(s.Name), s.Name)
+	w.WriteString("var errs paramsgen.ParamErrors\n")
+	for _, p := range s.Params {
+		if p.Slice {
+			g.sliceParam(s, p)
+		} else {
+			g.scalarParam(s, p)
+		}
+	}
+	w.WriteString("if len(errs) > 0 {\nreturn errs\n}\nreturn nil\n}\n")
+}
+
+// scalarParam writes the population of a scalar param field.
+func (g *generator) scalarParam(s paramsStruct, p field.Param) {
+	w := &g.funcs
+	fmt.Fprintf(w, "if raw, ok := paramsgen.%s(c, %q); ok {\n", locationName(p.In), p.Name)
+	fmt.Fprintf(w, "v, err := %s(raw)\n", parseFunc(p.GoType))
+	g.checks(s, p)
+	fmt.Fprintf(w, "if err != nil {\nerrs = append(errs, %s)\n} else {\n", paramError(p, "err"))
+	g.assign(p, "v")
+	w.WriteString("}\n")
+	g.missing(p)
+	w.WriteString("}\n")
+}
+
+// sliceParam writes the population of a slice param field, from all its values.
+func (g *generator) sliceParam(s paramsStruct, p field.Param) {
+	w := &g.funcs
+	fmt.Fprintf(w, "if raws, ok := paramsgen.%sValues(c, %q); ok {\n", locationName(p.In), p.Name)
+	if delimiter := p.Delimiter(); delimiter != "" {
+		fmt.Fprintf(w, "raws = paramsgen.SplitLists(raws, %q)\n", delimiter)
+	}
+	fmt.Fprintf(w, "values := make([]%s, len(raws))\n", p.GoType)
+	w.WriteString("var err error\n")
+	w.WriteString("for i, raw := range raws {\n")
+	fmt.Fprintf(w, "var v %s\n", p.GoType)
+	fmt.Fprintf(w, "v, err = %s(raw)\n", parseFunc(p.GoType))
+	g.checks(s, p)
+	w.WriteString("if err != nil {\nbreak\n}\nvalues[i] = v\n}\n")
+	fmt.Fprintf(w, "if err != nil {\nerrs = append(errs, %s)\n} else {\n", paramError(p, "err"))
+	g.assign(p, "values")
+	w.WriteString("}\n")
+	g.missing(p)
+	w.WriteString("}\n")
+}
+
+// checks writes the validation of a single value v, from its raw string, in the order of field.Param.Validate.
+func (g *generator) checks(s paramsStruct, p field.Param) {
+	w := &g.funcs
+	check := func(cond string, errExpr string) {
+		fmt.Fprintf(w, "if err == nil && %s {\nerr = %s\n}\n", cond, errExpr)
+	}
+	if p.Min != nil {
+		check("float64(v) < "+floatLiteral(*p.Min), "paramsgen.MinError(raw, "+floatLiteral(*p.Min)+")")
+	}
+	if p.Max != nil {
+		check("float64(v) > "+floatLiteral(*p.Max), "paramsgen.MaxError(raw, "+floatLiteral(*p.Max)+")")
+	}
+	if p.MultipleOf != nil {
+		check("!paramsgen.IsMultipleOf(float64(v), "+floatLiteral(*p.MultipleOf)+")", "paramsgen.MultipleOfError(raw, "+floatLiteral(*p.MultipleOf)+")")
+	}
+	if p.MinLength != nil {
+		g.imports["unicode/utf8"] = true
+		n := strconv.FormatUint(*p.MinLength, 10)
+		check("utf8.RuneCountInString(raw) < "+n, "paramsgen.MinLengthError(raw, "+n+")")
+	}
+	if p.MaxLength != nil {
+		g.imports["unicode/utf8"] = true
+		n := strconv.FormatUint(*p.MaxLength, 10)
+		check("utf8.RuneCountInString(raw) > "+n, "paramsgen.MaxLengthError(raw, "+n+")")
+	}
+	if p.Pattern != nil {
+		g.imports["regexp"] = true
+		pattern := patternVarName(s, p)
+		fmt.Fprintf(&g.vars, "%s = regexp.MustCompile(%s)\n", pattern, strconv.Quote(p.Pattern.String()))
+		check("!"+pattern+".MatchString(raw)", "paramsgen.PatternError(raw, "+pattern+")")
+	}
+	if p.Enum != nil {
+		conds := make([]string, len(p.Enum))
+		for i, enumValue := range p.Enum {
+			conds[i] = "v != " + literal(enumValue)
+		}
+		check(strings.Join(conds, " && "), "paramsgen.EnumError(raw, "+strconv.Quote(p.EnumString())+")")
+	}
+}
+
+// paramsVar writes the params of a params struct, as parsed by xfuego, for its route options.
+func (g *generator) paramsVar(s paramsStruct) {
+	w := &g.funcs
+	g.imports["reflect"] = true
+	fmt.Fprintf(w, "\n// %s are the params of a %s, documented by xfuego, see paramsgen.\n", paramsVarName(s.Name), s.Name)
+	fmt.Fprintf(w, "var %s = []paramsgen.Param{\n", paramsVarName(s.Name))
+	for _, p := range s.Params {
+		w.WriteString("{\n")
+		fmt.Fprintf(w, "Field: %q,\nIn: paramsgen.In%s,\nName: %q,\n", p.Field.Name, locationName(p.In), p.Name)
+		if p.Desc != "" {
+			fmt.Fprintf(w, "Desc: %s,\n", strconv.Quote(p.Desc))
+		}
+		fmt.Fprintf(w, "Type: reflect.TypeFor[%s](),\n", p.GoType)
+		if p.Slice {
+			w.WriteString("Slice: true,\n")
+		}
+		if p.Required {
+			w.WriteString("Required: true,\n")
+		}
+		if p.Deprecated {
+			w.WriteString("Deprecated: true,\n")
+		}
+		if p.Style != "" {
+			fmt.Fprintf(w, "Style: %q,\n", p.Style)
+		}
+		if p.Explode != nil {
+			fmt.Fprintf(w, "Explode: paramsgen.Ptr(%t),\n", *p.Explode)
+		}
+		if p.DefaultValue != nil {
+			if p.Slice {
+				fmt.Fprintf(w, "Default: %s,\n", anyLiterals(p.GoType, p.DefaultValue.([]any)))
+			} else {
+				fmt.Fprintf(w, "Default: %s,\n", typedLiteral(p.GoType, p.DefaultValue))
+			}
+		}
+		if p.Examples != nil {
+			w.WriteString("Examples: map[string]any{\n")
+			for _, name := range slices.Sorted(maps.Keys(p.Examples)) {
+				fmt.Fprintf(w, "%q: %s,\n", name, typedLiteral(p.GoType, p.Examples[name]))
+			}
+			w.WriteString("},\n")
+		}
+		if p.Enum != nil {
+			fmt.Fprintf(w, "Enum: %s,\n", anyLiterals(p.GoType, p.Enum))
+		}
+		for _, bound := range []struct {
+			name  string
+			value *float64
+		}{{"Min", p.Min}, {"Max", p.Max}, {"MultipleOf", p.MultipleOf}} {
+			if bound.value != nil {
+				fmt.Fprintf(w, "%s: paramsgen.Ptr[float64](%s),\n", bound.name, floatLiteral(*bound.value))
+			}
+		}
+		for _, bound := range []struct {
+			name  string
+			value *uint64
+		}{{"MinLength", p.MinLength}, {"MaxLength", p.MaxLength}} {
+			if bound.value != nil {
+				fmt.Fprintf(w, "%s: paramsgen.Ptr[uint64](%d),\n", bound.name, *bound.value)
+			}
+		}
+		if p.Pattern != nil {
+			fmt.Fprintf(w, "Pattern: %s,\n", patternVarName(s, p))
+		}
+		w.WriteString("},\n")
+	}
+	w.WriteString("}\n")
+}
+
+// missing writes the else branches of a missing param: its ParamError if it is required, or its default value.
+func (g *generator) missing(p field.Param) {
+	w := &g.funcs
+	if p.Required || p.In == field.InPath {
+		fmt.Fprintf(w, "} else {\nerrs = append(errs, %s)\n", paramError(p, "paramsgen.ErrMissing"))
+	} else if p.DefaultValue != nil {
+		w.WriteString("} else {\n")
+		if !p.Slice {
+			g.assign(p, literal(p.DefaultValue))
+			return
+		}
+		values := p.DefaultValue.([]any)
+		elems := make([]string, len(values))
+		for i, value := range values {
+			elems[i] = literal(value)
+		}
+		g.assign(p, fmt.Sprintf("[]%s{%s}", p.GoType, strings.Join(elems, ", ")))
+	}
+}
+
+// assign writes the assignment of a value expression to a param field, through a variable for pointer fields.
+func (g *generator) assign(p field.Param, value string) {
+	w := &g.funcs
+	if p.Field.Type.Kind() != reflect.Pointer {
+		fmt.Fprintf(w, "params.%s = %s\n", p.Field.Name, value)
+		return
+	}
+	if value != "v" && value != "values" {
+		if !p.Slice {
+			value = fmt.Sprintf("%s(%s)", p.Field.Type.Elem(), value)
+		}
+		fmt.Fprintf(w, "v := %s\n", value)
+		value = "v"
+	}
+	fmt.Fprintf(w, "params.%s = &%s\n", p.Field.Name, value)
+}
+
+// paramError returns the expression of the ParamError of a param.
+func paramError(p field.Param, errExpr string) string {
+	return fmt.Sprintf("paramsgen.NewParamError(%q, paramsgen.In%s, %s)", p.Name, locationName(p.In), errExpr)
+}
+
+// locationName returns the name of a param location in paramsgen, e.g. "Query" for paramsgen.Query and InQuery.
+func locationName(in field.In) string {
+	name := in.String()
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// parseFunc returns the paramsgen function converting a value of type t.
+func parseFunc(t reflect.Type) string {
+	switch {
+	case t.Kind() == reflect.String:
+		return "paramsgen.ParseString"
+	case t.Kind() == reflect.Bool:
+		return "paramsgen.ParseBool"
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		return "paramsgen.ParseInt[" + t.String() + "]"
+	case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64:
+		return "paramsgen.ParseUint[" + t.String() + "]"
+	}
+	return "paramsgen.ParseFloat[" + t.String() + "]"
+}
+
+// typedLiteral returns the Go expression of a converted tag value of type t, e.g. int64(20), whose type is t when
+// assigned to an any.
+func typedLiteral(t reflect.Type, value any) string {
+	if t.Kind() == reflect.String || t.Kind() == reflect.Bool {
+		return literal(value)
+	}
+	return t.String() + "(" + literal(value) + ")"
+}
+
+// anyLiterals returns the Go expression of the []any of converted tag values of type t, e.g. enum values.
+func anyLiterals(t reflect.Type, values []any) string {
+	elems := make([]string, len(values))
+	for i, value := range values {
+		elems[i] = typedLiteral(t, value)
+	}
+	return "[]any{" + strings.Join(elems, ", ") + "}"
+}
+
+// literal returns the Go literal of a converted tag value, e.g. a default or enum value.
+func literal(value any) string {
+	switch value := value.(type) {
+	case string:
+		return strconv.Quote(value)
+	case float32:
+		return strconv.FormatFloat(float64(value), 'g', -1, 32)
+	case float64:
+		return floatLiteral(value)
+	}
+	return fmt.Sprint(value)
+}
+
+func floatLiteral(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// funcName returns the name of the populate function of a params struct, e.g. populateListParams.
+func funcName(typeName string) string {
+	return "populate" + strings.ToUpper(typeName[:1]) + typeName[1:]
+}
+
+// paramsVarName returns the name of the params variable of a params struct, e.g. listParamsParams.
+func paramsVarName(typeName string) string {
+	return lowerFirst(typeName) + "Params"
+}
+
+// patternVarName returns the name of the variable of the compiled pattern of a param, e.g. listParamsTagsPattern.
+func patternVarName(s paramsStruct, p field.Param) string {
+	return lowerFirst(s.Name) + p.Field.Name + "Pattern"
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
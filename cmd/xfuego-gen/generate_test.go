@@ -0,0 +1,160 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crunk1/xfuego/internal/paramspopulator"
+)
+
+func parseSource(t *testing.T, src string) []*ast.File {
+	file, err := parser.ParseFile(token.NewFileSet(), "params.go", src, parser.SkipObjectResolution|parser.ParseComments)
+	require.NoError(t, err)
+	return []*ast.File{file}
+}
+
+// ListParams is declared both in Go, for its reflective fingerprint, and in listParamsSrc.
+type ListParams struct {
+	Limit  int       `query:"limit,,default=20,min=1,max=100"`
+	Sort   *string   `query:"sort,,enum=asc|desc"`
+	IDs    []int64   `query:"id,,style=pipeDelimited,explode=false"`
+	Tags   *[]string `header:"X-Tags,,default=a|b,pattern=^[a-z]+$"`
+	Token  string    `cookie:"token,,minLength=2"`
+	ID     uint      `path:"id"`
+	Other  map[string]int
+	hidden bool
+}
+
+const listParamsSrc = "package api\n\ntype ListParams struct {\n" +
+	"Limit  int       `query:\"limit,,default=20,min=1,max=100\"`\n" +
+	"Sort   *string   `query:\"sort,,enum=asc|desc\"`\n" +
+	"IDs    []int64   `query:\"id,,style=pipeDelimited,explode=false\"`\n" +
+	"Tags   *[]string `header:\"X-Tags,,default=a|b,pattern=^[a-z]+$\"`\n" +
+	"Token  string    `cookie:\"token,,minLength=2\"`\n" +
+	"ID     uint      `path:\"id\"`\n" +
+	"Other  map[string]int\n" +
+	"hidden bool\n" +
+	"}\n"
+
+func TestGenerate(t *testing.T) {
+	a := assert.New(t)
+	src, err := generate("api", parseSource(t, listParamsSrc), []string{"ListParams"}, nil)
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "listparams_xfuego.go", src, 0)
+	a.NoError(err)
+
+	tt := reflect.TypeFor[ListParams]()
+	fields := make([]reflect.StructField, tt.NumField())
+	for i := range fields {
+		fields[i] = tt.Field(i)
+	}
+	a.Contains(string(src), "paramsgen.Register(populateListParams, listParamsParams, `"+paramspopulator.Fingerprint(fields)+"`)")
+
+	for _, want := range []string{
+		"listParamsTagsPattern = regexp.MustCompile(\"^[a-z]+$\")",
+		"func populateListParams(c paramsgen.Getters, params *ListParams) error {",
+		"if raw, ok := paramsgen.Query(c, \"limit\"); ok {",
+		"v, err := paramsgen.ParseInt[int](raw)",
+		"if err == nil && float64(v) < 1 {\n\t\t\terr = paramsgen.MinError(raw, 1)",
+		"params.Limit = 20",
+		"if err == nil && v != \"asc\" && v != \"desc\" {\n\t\t\terr = paramsgen.EnumError(raw, \"asc|desc\")",
+		"params.Sort = &v",
+		"raws = paramsgen.SplitLists(raws, \"|\")",
+		"v, err = paramsgen.ParseInt[int64](raw)",
+		"errs = append(errs, paramsgen.NewParamError(\"id\", paramsgen.InQuery, paramsgen.ErrMissing))",
+		"if raws, ok := paramsgen.HeaderValues(c, \"X-Tags\"); ok {",
+		"raws = paramsgen.SplitLists(raws, \",\")",
+		"v := []string{\"a\", \"b\"}\n\t\tparams.Tags = &v",
+		"if err == nil && utf8.RuneCountInString(raw) < 2 {",
+		"if raw, ok := paramsgen.Path(c, \"id\"); ok {",
+		"v, err := paramsgen.ParseUint[uint](raw)",
+		"var listParamsParams = []paramsgen.Param{",
+		"Field:   \"Limit\",\n\t\tIn:      paramsgen.InQuery,\n\t\tName:    \"limit\",\n\t\tType:    reflect.TypeFor[int](),\n" +
+			"\t\tDefault: int(20),\n\t\tMin:     paramsgen.Ptr[float64](1),\n\t\tMax:     paramsgen.Ptr[float64](100),\n\t},",
+		"Enum:  []any{\"asc\", \"desc\"},",
+		"Slice:    true,\n\t\tRequired: true,\n\t\tStyle:    \"pipeDelimited\",\n\t\tExplode:  paramsgen.Ptr(false),",
+		"Default: []any{\"a\", \"b\"},\n\t\tPattern: listParamsTagsPattern,",
+		"MinLength: paramsgen.Ptr[uint64](2),",
+		"Type:     reflect.TypeFor[uint](),",
+	} {
+		a.Contains(string(src), want)
+	}
+	a.NotContains(string(src), "Other")
+	a.NotContains(string(src), "hidden")
+}
+
+func TestGenerate_form(t *testing.T) {
+	a := assert.New(t)
+	const src = "package api\n\ntype LoginParams struct {\n" +
+		"User   string   `form:\"user,,minLength=2\"`\n" +
+		"Scopes []string `form:\"scope\"`\n" +
+		"}\n"
+	gen, err := generate("api", parseSource(t, src), []string{"LoginParams"}, nil)
+	require.NoError(t, err)
+	a.Contains(string(gen), "if raw, ok := paramsgen.Form(c, \"user\"); ok {")
+	a.Contains(string(gen), "if raws, ok := paramsgen.FormValues(c, \"scope\"); ok {")
+	a.Contains(string(gen), "errs = append(errs, paramsgen.NewParamError(\"user\", paramsgen.InForm, paramsgen.ErrMissing))")
+	a.NotContains(string(gen), "SplitLists")
+}
+
+func TestGenerate_docs(t *testing.T) {
+	a := assert.New(t)
+	const src = "package api\n\ntype SearchParams struct {\n" +
+		"// Query is the search query, see\n// [the syntax](https://example.com/syntax).\n//\n// Case-insensitive.\n" +
+		"Query string `query:\"q\"`\n" +
+		"// Sort is documented in its tag.\nSort string `query:\"sort,Sort order\"`\n" +
+		"Pagination\n" +
+		"// Secret is not a param.\nSecret string\n" +
+		"}\n\ntype Pagination struct {\n// Items to skip.\nOffset int `query:\"offset\"`\n}\n"
+	gen, err := generate("api", parseSource(t, src), nil, []string{"SearchParams", "Pagination"})
+	require.NoError(t, err)
+	a.Contains(string(gen), "func init() {\n"+
+		"\tparamsgen.RegisterDocs[SearchParams](map[string]string{\n"+
+		"\t\t\"Query\": \"Query is the search query, see\\n[the syntax](https://example.com/syntax).\\n\\nCase-insensitive.\",\n"+
+		"\t\t\"Sort\":  \"Sort is documented in its tag.\",\n"+
+		"\t})\n"+
+		"\tparamsgen.RegisterDocs[Pagination](map[string]string{\n"+
+		"\t\t\"Offset\": \"Items to skip.\",\n"+
+		"\t})\n}")
+	a.NotContains(string(gen), "Secret")
+
+	_, err = generate("api", parseSource(t, src), nil, []string{"Params"})
+	a.EqualError(err, "type Params not found")
+}
+
+func TestGenerate_errors(t *testing.T) {
+	type testCase struct {
+		name    string
+		src     string
+		typeArg string
+		wantErr string
+	}
+	tests := []testCase{
+		{"not found", "package api\n", "Params", "type Params not found"},
+		{"not a struct", "package api\ntype Params int\n", "Params", "type Params is not a non-generic struct"},
+		{"generic", "package api\ntype Params[T any] struct{}\n", "Params", "type Params is not a non-generic struct"},
+		{"embedded", "package api\ntype Params struct{ Pagination }\n", "Params", "Params: embedded fields are not supported"},
+		{"unsupported type", "package api\ntype Params struct{ At time.Time `query:\"at\"` }\n", "Params",
+			"Params: param field type is not supported, leave the struct out of -type to populate it with reflection: field=At"},
+		{"invalid tag", "package api\ntype Params struct{ Limit int `query:\"limit,,min=x\"` }\n", "Params",
+			"Params: param opt 'min' must be a number"},
+		{"alias", "package api\ntype Params struct{ Limit int `query:\"limit,,alias=size\"` }\n", "Params",
+			"Params: param opt 'alias' is not supported, leave the struct out of -type to populate it with reflection: field=Limit"},
+		{"unexported", "package api\ntype Params struct{ limit int `query:\"limit\"` }\n", "Params",
+			"Params: param field must be exported: field=limit"},
+		{"duplicate", "package api\ntype Params struct{ Limit int `query:\"limit\"`; Size int `query:\"limit\"` }\n", "Params",
+			"Params: duplicate query param name limit: fields=Limit,Size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generate("api", parseSource(t, tt.src), []string{tt.typeArg}, nil)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
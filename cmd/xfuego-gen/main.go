@@ -0,0 +1,107 @@
+// Command xfuego-gen generates the populate functions of xfuego params structs: plain Go functions that get, convert
+// and validate each param of the struct, which xfuego uses instead of populating the struct with reflection. Along
+// with each function, it generates the params of the struct as xfuego parses them from its tags, from which xfuego
+// builds the route options documenting the params instead of parsing the struct with reflection. It also generates
+// the registration of the doc comments of params struct fields, used as the OpenAPI descriptions of the params whose
+// tag has no description.
+//
+// Usage, in the package declaring the params structs:
+//
+//	//go:generate go run github.com/crunk1/xfuego/cmd/xfuego-gen -type=ListParams,GetParams -docs=ListParams,Pagination
+//
+// The generated file registers the functions and params with xfuego when the package is initialized. The functions
+// behave like xfuego's reflective populator, same errors included. Route registration fails, like for an invalid
+// params struct, if their struct has changed since they were generated.
+//
+// Supported param fields have a bool, string or number type, a pointer to one, or a slice of one, with any of the
+// param tag options but alias. Structs with other fields, e.g. Nullable, text types, maps, nested or embedded structs,
+// are not supported: leave them out of -type, so that xfuego keeps populating them with reflection.
+//
+// -docs takes any params struct, including the embedded and nested structs of params structs, whose field docs are
+// registered separately: list them too. Doc comments are used verbatim, e.g. Markdown, and only apply to the param
+// fields declared in the package, so run go generate again when they change.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("xfuego-gen: ")
+	typeNames := flag.String("type", "", "comma-separated list of params struct type names to generate populate functions and params of")
+	docTypeNames := flag.String("docs", "", "comma-separated list of struct type names to register the field docs of")
+	output := flag.String("output", "", "output file name; default srcdir/<type>_xfuego.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: xfuego-gen [-type T[,T...]] [-docs T[,T...]] [-output file] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if (*typeNames == "" && *docTypeNames == "") || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	types, docTypes := splitNames(*typeNames), splitNames(*docTypeNames)
+	if *output == "" {
+		*output = filepath.Join(dir, strings.ToLower(append(types, docTypes...)[0])+"_xfuego.go")
+	}
+
+	pkgName, files, err := parsePackage(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(pkgName, files, types, docTypes)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// splitNames splits a comma-separated list of type names.
+func splitNames(names string) []string {
+	if names == "" {
+		return nil
+	}
+	return strings.Split(names, ",")
+}
+
+// parsePackage parses the non-test Go files of the package in dir, with their comments.
+func parsePackage(dir string) (pkgName string, files []*ast.File, err error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, err
+	}
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution|parser.ParseComments)
+		if err != nil {
+			return "", nil, err
+		}
+		if pkgName != "" && file.Name.Name != pkgName {
+			return "", nil, fmt.Errorf("%s: several packages: %s and %s", dir, pkgName, file.Name.Name)
+		}
+		pkgName = file.Name.Name
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return "", nil, fmt.Errorf("%s: no Go files", dir)
+	}
+	return pkgName, files, nil
+}
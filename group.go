@@ -0,0 +1,108 @@
+package xfuego
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/go-fuego/fuego"
+
+	"github.com/crunk1/xfuego/internal/paramspopulator"
+	"github.com/crunk1/xfuego/internal/paramsrouteoptions"
+)
+
+// group is the params struct shared by the routes of a group created with Group.
+type group struct {
+	// populate returns a populated *GroupParamsT, and validate calls its Validate method, if any.
+	populate func(c paramspopulator.ContextGetters) (any, error)
+	validate func(ctx context.Context, params any) error
+
+	// source reports the source of a GroupParamsT param, see Request.ParamSource, and deprecated whether the request
+	// has a deprecated GroupParamsT param, if any.
+	source     func(c paramspopulator.ContextGetters, name string) (ParamSource, bool)
+	deprecated func(c paramspopulator.ContextGetters) bool
+}
+
+// routeGroups maps the *openapi3.Operation of a route being registered to its groups, innermost first. Each group adds
+// itself with its routeOption, which is one of the group's route options, so that it also applies to the routes of a
+// plain fuego.Group nested in the group; register then takes the route's groups with takeRouteGroups. The entries of
+// routes registered on a group with fuego rather than xfuego are never taken: they are kept for the life of the
+// program, one per such route, along with the operation they hold.
+var routeGroups sync.Map
+
+// Group is fuego.Group, with GroupParamsT params shared by every route registered on the returned group with xfuego:
+//   - they are documented on every route, as if declared by the group's options
+//   - they are populated and validated along with each route's own params, and read with GroupParams
+//
+// The path params of GroupParamsT must match the `{name}` wildcards of the group path, see Get. Groups nested in the
+// returned group, whether created with Group or fuego.Group, share its params.
+func Group[GroupParamsT any](s *fuego.Server, path string, opts ...func(*fuego.BaseRoute)) *fuego.Server {
+	g, err := TryGroup[GroupParamsT](s, path, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// TryGroup is Group, returning a *ParamsStructError instead of panicking if GroupParamsT is invalid.
+func TryGroup[GroupParamsT any](s *fuego.Server, path string, opts ...func(*fuego.BaseRoute)) (*fuego.Server, error) {
+	if err := paramspopulator.CheckGenerated[GroupParamsT](); err != nil {
+		return nil, err
+	}
+	paramsRouteOptions, err := paramsrouteoptions.Generate[GroupParamsT](basePath(s), path)
+	if err != nil {
+		return nil, err
+	}
+	if paramspopulator.GenerateParseForm[GroupParamsT]() != nil {
+		// The form params are the request body of each route.
+		return nil, &ParamsStructError{Type: reflect.TypeOf((*GroupParamsT)(nil)).Elem(), Errors: []string{"form params are not supported for group params"}}
+	}
+	populateParams := paramspopulator.Generate[GroupParamsT]()
+	validateParams := paramspopulator.GenerateValidate[GroupParamsT]()
+	g := &group{
+		populate: func(c paramspopulator.ContextGetters) (any, error) {
+			params := new(GroupParamsT)
+			return params, populateParams(c, params)
+		},
+		validate: func(ctx context.Context, params any) error {
+			return validateParams(ctx, params.(*GroupParamsT))
+		},
+		source:     paramspopulator.GenerateSource[GroupParamsT](),
+		deprecated: paramspopulator.GenerateDeprecated[GroupParamsT](),
+	}
+	return fuego.Group(s, path, append(opts, append(paramsRouteOptions, g.routeOption)...)...), nil
+}
+
+// GroupParams returns the GroupParamsT params of the group of req's route, see Group. Nested groups are searched
+// innermost first. It panics if no group of the route has GroupParamsT params.
+func GroupParams[GroupParamsT any, ReqParamsT any, ReqBodyT any](req Request[ReqParamsT, ReqBodyT]) GroupParamsT {
+	if r, ok := req.(*request[ReqParamsT, ReqBodyT]); ok {
+		for _, params := range r.groupParams {
+			if params, ok := params.(*GroupParamsT); ok {
+				return *params
+			}
+		}
+	}
+	panic(fmt.Sprintf("xfuego: the route has no group params of type %v", reflect.TypeOf((*GroupParamsT)(nil)).Elem()))
+}
+
+// routeOption adds g to the groups of the route being registered, see routeGroups. The options of enclosing groups run
+// first, so g is the innermost group so far.
+func (g *group) routeOption(r *fuego.BaseRoute) {
+	gs := []*group{g}
+	if outer, ok := routeGroups.Load(r.Operation); ok {
+		gs = append(gs, outer.([]*group)...)
+	}
+	routeGroups.Store(r.Operation, gs)
+}
+
+// takeRouteGroups returns the groups of the route being registered, innermost first, once the route options of its
+// groups have run.
+func takeRouteGroups(r *fuego.BaseRoute) []*group {
+	gs, ok := routeGroups.LoadAndDelete(r.Operation)
+	if !ok {
+		return nil
+	}
+	return gs.([]*group)
+}
@@ -0,0 +1,130 @@
+package xfuego_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-fuego/fuego"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/crunk1/xfuego"
+)
+
+type orgParams struct {
+	OrgID  int    `path:"orgId"`
+	Tenant string `header:"X-Tenant"`
+}
+
+func (p orgParams) Validate() error {
+	if p.Tenant == "root" {
+		return xfuego.ParamError{Name: "X-Tenant", Reason: "is reserved"}
+	}
+	return nil
+}
+
+type projectParams struct {
+	ProjectID string `path:"projectId"`
+}
+
+type listParams struct {
+	Limit int `query:"limit,,default=10"`
+}
+
+func TestGroup(t *testing.T) {
+	a := assert.New(t)
+	s := newServer()
+	g := xfuego.Group[orgParams](s, "/orgs/{orgId}")
+	xfuego.Get(g, "/items", func(req xfuego.Request[listParams, xfuego.None]) (orgParams, error) {
+		a.Equal(10, req.Params().Limit)
+		a.Equal(xfuego.ParamProvided, req.ParamSource("X-Tenant"))
+		return xfuego.GroupParams[orgParams](req), nil
+	})
+
+	w := serve(s, http.MethodGet, "/orgs/7/items", func(r *http.Request) { r.Header.Set("X-Tenant", "acme") })
+	a.Equal(http.StatusOK, w.Code)
+	a.JSONEq(`{"OrgID": 7, "Tenant": "acme"}`, w.Body.String())
+
+	// Group and route params are populated together
+	w = serve(s, http.MethodGet, "/orgs/seven/items?limit=ten")
+	a.Equal(http.StatusBadRequest, w.Code)
+	a.Equal([]string{
+		`path orgId: value is not a valid int: "seven"`,
+		"header X-Tenant: required value is missing",
+		`query limit: value is not a valid int: "ten"`,
+	}, decodeProblem(t, w).paramErrors())
+
+	// The group params are validated
+	w = serve(s, http.MethodGet, "/orgs/7/items", func(r *http.Request) { r.Header.Set("X-Tenant", "root") })
+	a.Equal(http.StatusBadRequest, w.Code)
+	a.Equal([]string{"header X-Tenant: is reserved"}, decodeProblem(t, w).paramErrors())
+
+	operation := s.OutputOpenAPISpec().Paths.Find("/orgs/{orgId}/items").Get
+	a.NotNil(operation.Parameters.GetByInAndName("path", "orgId"))
+	a.NotNil(operation.Parameters.GetByInAndName("header", "X-Tenant"))
+	a.NotNil(operation.Parameters.GetByInAndName("query", "limit"))
+}
+
+func TestGroup_nested(t *testing.T) {
+	a := assert.New(t)
+	s := newServer()
+	org := xfuego.Group[orgParams](s, "/orgs/{orgId}")
+	v1 := fuego.Group(org, "/v1")
+	project := xfuego.Group[projectParams](v1, "/projects/{projectId}")
+
+	// A route of a plain fuego.Group nested in a group has the group's params
+	xfuego.Get(v1, "/items", func(req xfuego.Request[xfuego.None, xfuego.None]) (int, error) {
+		return xfuego.GroupParams[orgParams](req).OrgID, nil
+	})
+	xfuego.Get(project, "/items", func(req xfuego.Request[xfuego.None, xfuego.None]) (string, error) {
+		return xfuego.GroupParams[orgParams](req).Tenant + " " + xfuego.GroupParams[projectParams](req).ProjectID, nil
+	})
+
+	w := serve(s, http.MethodGet, "/orgs/7/v1/items", func(r *http.Request) { r.Header.Set("X-Tenant", "acme") })
+	a.Equal(http.StatusOK, w.Code)
+	a.JSONEq("7", w.Body.String())
+
+	w = serve(s, http.MethodGet, "/orgs/7/v1/items")
+	a.Equal(http.StatusBadRequest, w.Code)
+	a.Equal([]string{"header X-Tenant: required value is missing"}, decodeProblem(t, w).paramErrors())
+
+	w = serve(s, http.MethodGet, "/orgs/7/v1/projects/p1/items", func(r *http.Request) { r.Header.Set("X-Tenant", "acme") })
+	a.Equal(http.StatusOK, w.Code)
+	a.Equal("acme p1", w.Body.String())
+}
+
+func TestGroupParams_noGroup(t *testing.T) {
+	a := assert.New(t)
+	s := newServer()
+	g := xfuego.Group[projectParams](s, "/projects/{projectId}")
+	xfuego.Get(g, "/items", func(req xfuego.Request[xfuego.None, xfuego.None]) (string, error) {
+		a.PanicsWithValue("xfuego: the route has no group params of type xfuego_test.orgParams", func() {
+			xfuego.GroupParams[orgParams](req)
+		})
+		return "", nil
+	})
+
+	w := serve(s, http.MethodGet, "/projects/p1/items")
+	a.Equal(http.StatusOK, w.Code)
+}
+
+func TestTryGroup(t *testing.T) {
+	type formParams struct {
+		Name string `form:"name"`
+	}
+	a := assert.New(t)
+	s := newServer()
+
+	_, err := xfuego.TryGroup[orgParams](s, "/orgs")
+	var structErr *xfuego.ParamsStructError
+	a.True(errors.As(err, &structErr), err)
+
+	_, err = xfuego.TryGroup[formParams](s, "/forms")
+	a.True(errors.As(err, &structErr), err)
+	a.Equal([]string{"form params are not supported for group params"}, structErr.Errors)
+
+	g, err := xfuego.TryGroup[orgParams](s, "/orgs/{orgId}")
+	a.NoError(err)
+	a.NotNil(g)
+	a.Panics(func() { xfuego.Group[orgParams](s, "/orgs") })
+}
@@ -0,0 +1,25 @@
+package field
+
+import (
+	"reflect"
+	"sync"
+)
+
+// docs holds the field doc comments of params structs, registered by the code generated by xfuego-gen:
+// struct type -> field name -> doc comment.
+var docs sync.Map
+
+// RegisterDocs registers the doc comments of the fields of the struct t, keyed by field name. A registered doc comment
+// is the description of the param, or nested struct param, declared by its field, unless its tag has a description.
+func RegisterDocs(t reflect.Type, fieldDocs map[string]string) {
+	docs.Store(t, fieldDocs)
+}
+
+// FieldDoc returns the registered doc comment of a field of the struct t, or "" if there is none.
+func FieldDoc(t reflect.Type, fieldName string) string {
+	fieldDocs, ok := docs.Load(t)
+	if !ok {
+		return ""
+	}
+	return fieldDocs.(map[string]string)[fieldName]
+}
@@ -9,4 +9,22 @@ const (
 	InPath
 	InHeader
 	InCookie
+	InForm // A field of an application/x-www-form-urlencoded request body.
 )
+
+// String returns the OpenAPI `in` value of the location, or "form" for form body fields.
+func (in In) String() string {
+	switch in {
+	case InQuery:
+		return "query"
+	case InPath:
+		return "path"
+	case InHeader:
+		return "header"
+	case InCookie:
+		return "cookie"
+	case InForm:
+		return "form"
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+package field
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Object is a nested struct query param field, e.g. a `Filter FilterParams` field tagged `query:"filter"`. The params of
+// the nested struct are bound with the object's name as a prefix: `?filter[status]=x` for style deepObject (the
+// default), or `?filter.status=x` with a custom separator, e.g. `query:"filter,,separator=."`.
+type Object struct {
+	Name      string
+	Desc      string
+	Separator string // "" for style deepObject.
+}
+
+// ParamName returns the prefixed name of a param of the object.
+func (o *Object) ParamName(name string) string {
+	if o.Separator == "" {
+		return o.Name + "[" + name + "]"
+	}
+	return o.Name + o.Separator + name
+}
+
+// PropertyName returns the unprefixed name of a param of the object, i.e. the inverse of ParamName.
+func (o *Object) PropertyName(paramName string) string {
+	if o.Separator == "" {
+		return strings.TrimSuffix(strings.TrimPrefix(paramName, o.Name+"["), "]")
+	}
+	return strings.TrimPrefix(paramName, o.Name+o.Separator)
+}
+
+// parseObject returns the Object of a nested struct param field, or nil if the field is not a nested struct param.
+func parseObject(field reflect.StructField) *Object {
+	p := parseTag(field)
+	if p.In == InNone {
+		return nil
+	}
+	t := field.Type
+	if t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct && !isText(t.Elem()) {
+		panic("param nested struct field must be a struct value, not a pointer: field=" + field.Name)
+	}
+	if t.Kind() != reflect.Struct || isText(t) {
+		return nil
+	}
+	if p.In != InQuery {
+		panic("param nested struct fields are only supported for query params: field=" + field.Name)
+	}
+	if !field.IsExported() {
+		panic("param field must be exported: field=" + field.Name)
+	}
+
+	o := &Object{Name: p.Name, Desc: p.Desc, Separator: p.Separator}
+	if o.Name == "" {
+		o.Name = field.Name
+	}
+	if p.Style != "" && (p.Style != StyleDeepObject || p.Separator != "") {
+		panic("param nested struct style must be deepObject, or no style with a separator: field=" + field.Name)
+	}
+	// Only the name, description, style and separator apply to the object itself.
+	p.In, p.Name, p.Desc, p.Style, p.Separator = InNone, "", "", "", ""
+	if !reflect.DeepEqual(p, Param{}) {
+		panic("param nested struct field only supports the style and separator opts: field=" + field.Name)
+	}
+	return o
+}
@@ -2,67 +2,243 @@ package field
 
 import (
 	"reflect"
-	"strconv"
+	"regexp"
+	"strings"
+
+	"github.com/samber/lo"
 )
 
-// Parse parses a field's type and tag information
-func Parse(field reflect.StructField) (in In, goKind reflect.Kind, required bool, nullable bool, strconvFn func(string) any, name string, desc string, defaultValue any, examples map[string]any) {
-	in, name, desc, defaultValue, examples = parseTag(field)
-	if in == InNone {
-		return InNone, 0, false, false, nil, "", "", nil, nil
+// Param is the parsed type and tag information of a param struct field.
+type Param struct {
+	// Field is the param struct field. For params promoted from embedded structs, Embeddings is the path to the
+	// struct containing Field, outermost first.
+	Field      reflect.StructField
+	Embeddings []Embedding
+
+	In     In
+	GoType reflect.Type // For slices, the type of the slice elements. For maps, the type of the map values.
+	GoKind reflect.Kind // GoType.Kind()
+	Slice  bool
+
+	// Map is set for map[string]T params, which collect the values of all the keys prefixed with Name, e.g.
+	// `?label.env=prod&label.team=core` for the name "label.". The map keys are unprefixed.
+	Map bool
+
+	// Text is set for text types: encoding.TextUnmarshaler implementations and time.Duration. They are converted from
+	// their text representation, and documented as OpenAPI strings.
+	Text bool
+
+	// Layout is the time.Parse layout of time.Time and types.Date params, if not their default.
+	Layout string
+
+	// Separator is the tag option of nested struct params, see Object.
+	Separator string
+
+	// Object is set for the params of a nested struct param field. Their Name includes the object's prefix.
+	Object *Object
+
+	Required bool
+	Nullable bool
+
+	// StrconvFn converts a single string value to GoType. For slices and maps, it converts a single element.
+	StrconvFn func(string) (any, error)
+
+	Name string
+	Desc string
+
+	// Aliases are other names the param is also read from, e.g. its former names, documented as deprecated params.
+	// Deprecated marks the param itself as deprecated.
+	Aliases    []string
+	Deprecated bool
+
+	// Style and Explode are the OpenAPI serialization of slice params. They are always set for slices.
+	Style   string
+	Explode *bool
+
+	// DefaultValue and Examples values are already converted with StrconvFn. For slices, they are []any.
+	DefaultValue any
+	Examples     map[string]any
+
+	// Validation constraints, see Validate. For slices, they apply to each element.
+	Min        *float64
+	Max        *float64
+	MultipleOf *float64
+	MinLength  *uint64
+	MaxLength  *uint64
+	Pattern    *regexp.Regexp
+	Enum       []any // Converted with StrconvFn.
+}
+
+// Parse parses a field's type and tag information. Non-param fields are returned with In == InNone.
+// Embedded structs are not walked, see ParseStruct.
+func Parse(field reflect.StructField) (p Param) {
+	p = parseTag(field)
+	if p.In == InNone {
+		return Param{}
 	}
 	if !field.IsExported() {
 		panic("param field must be exported: field=" + field.Name)
 	}
-	if field.Anonymous { // TODO: support public anonymous fields - embedded structs
-		panic("param anonymous field support is not yet implemented: field=" + field.Name)
+	p.Field = field
+	p.GoType, p.Slice, p.Map, p.Text, p.Required, p.Nullable = parseType(field)
+	p.GoKind = p.GoType.Kind()
+	if p.Slice && p.In == InPath {
+		panic("param field slice type is not supported for path params: field=" + field.Name)
+	}
+	if p.Map && p.In == InPath {
+		panic("param field map type is not supported for path params: field=" + field.Name)
+	}
+	if p.Map && p.In == InForm {
+		panic("param field map type is not supported for form params: field=" + field.Name)
+	}
+	if p.Map && (p.DefaultValue != nil || p.Examples != nil) {
+		panic("param opts 'default' and 'example' are not supported for map params: field=" + field.Name)
 	}
-	goKind, required, nullable = parseType(field)
+	if p.Aliases != nil && (p.In == InPath || p.Map) {
+		panic("param opt 'alias' is not supported for path and map params: field=" + field.Name)
+	}
+
+	if p.Separator != "" {
+		panic("param opt 'separator' is only supported for nested struct params: field=" + field.Name)
+	}
+	p.Style, p.Explode = parseStyle(field, p)
 
 	// Name defaulting
-	if name == "" {
-		name = field.Name
+	if p.Name == "" {
+		p.Name = field.Name
 	}
 
 	// Not required if defaultValue is set
-	required = defaultValue == nil && required
+	p.Required = p.DefaultValue == nil && p.Required
 
 	// Set the string conversion function based on the field type.
-	if goKind == reflect.Bool {
-		strconvFn = strconvBool
-	} else if goKind == reflect.Int {
-		strconvFn = strconvInt
-	} else if goKind == reflect.String {
-		strconvFn = strconvString
+	if p.Layout != "" {
+		if p.GoType != timeType && p.GoType != dateType {
+			panic("param opt 'layout' is only supported for time.Time and Date params: field=" + field.Name)
+		}
+		p.StrconvFn = strconvTime(p.GoType, p.Layout)
+	} else if p.GoType == durationType {
+		p.StrconvFn = strconvDuration
+	} else if p.Text {
+		p.StrconvFn = strconvText(p.GoType)
+	} else {
+		p.StrconvFn = strconvFns[p.GoKind]
 	}
 
+	p.Enum = parseConstraints(field, p)
+
 	// Convert defaultValue and example strings.
-	if defaultValue != nil {
-		defaultValue = strconvFn(defaultValue.(string))
+	if p.DefaultValue != nil {
+		p.DefaultValue = p.strconvTagValue(field, p.DefaultValue.(string))
 	}
-	for exampleName, exampleValue := range examples {
-		examples[exampleName] = strconvFn(exampleValue.(string))
+	for exampleName, exampleValue := range p.Examples {
+		p.Examples[exampleName] = p.strconvTagValue(field, exampleValue.(string))
 	}
 
-	return
+	return p
 }
 
-func strconvBool(value string) any {
-	result, err := strconv.ParseBool(value)
-	if err != nil {
-		panic("param string value is not a bool: " + value)
+// Serialization styles, as defined by OpenAPI.
+const (
+	StyleDeepObject     = "deepObject"
+	StyleForm           = "form"
+	StyleSimple         = "simple"
+	StyleSpaceDelimited = "spaceDelimited"
+	StylePipeDelimited  = "pipeDelimited"
+)
+
+// parseStyle validates the style and explode param opts and fills in the OpenAPI defaults for the param location.
+// Only slice params have a serialization style:
+//   - query and form: form (default), spaceDelimited or pipeDelimited; explode defaults to true for form, i.e.
+//     repeated values, and must be false for the delimited styles
+//   - header: simple; explode makes no difference for slices
+//   - cookie: form; explode must be false, i.e. a single comma-separated value
+func parseStyle(field reflect.StructField, p Param) (style string, explode *bool) {
+	if !p.Slice {
+		if p.Style != "" || p.Explode != nil {
+			panic("param opts 'style' and 'explode' are only supported for slice params: field=" + field.Name)
+		}
+		return "", nil
 	}
-	return result
+	style, explode = p.Style, p.Explode
+	switch p.In {
+	case InQuery, InForm:
+		if style == "" {
+			style = StyleForm
+		}
+		if style != StyleForm && style != StyleSpaceDelimited && style != StylePipeDelimited {
+			panic(p.In.String() + " param style must be form|spaceDelimited|pipeDelimited: field=" + field.Name)
+		}
+		if explode == nil {
+			explode = lo.ToPtr(style == StyleForm)
+		}
+		if style != StyleForm && *explode {
+			panic(p.In.String() + " param explode=true is not supported with style=" + style + ": field=" + field.Name)
+		}
+	case InHeader:
+		if style == "" {
+			style = StyleSimple
+		}
+		if style != StyleSimple {
+			panic("header param style must be simple: field=" + field.Name)
+		}
+		if explode == nil {
+			explode = lo.ToPtr(false)
+		}
+	case InCookie:
+		if style == "" {
+			style = StyleForm
+		}
+		if style != StyleForm {
+			panic("cookie param style must be form: field=" + field.Name)
+		}
+		if explode == nil {
+			explode = lo.ToPtr(false)
+		}
+		if *explode {
+			panic("cookie param explode=true is not supported: field=" + field.Name)
+		}
+	}
+	return style, explode
 }
 
-func strconvInt(value string) any {
-	result, err := strconv.Atoi(value)
-	if err != nil {
-		panic("param string value is not an int: " + value)
+// Delimiter returns the separator of a non-exploded slice param's values, or "" if values are not delimited.
+func (p Param) Delimiter() string {
+	if !p.Slice || ((p.In == InQuery || p.In == InForm) && *p.Explode) {
+		return ""
+	}
+	switch p.Style {
+	case StyleSpaceDelimited:
+		return " "
+	case StylePipeDelimited:
+		return "|"
 	}
-	return result
+	return ","
 }
 
-func strconvString(value string) any {
-	return value
+// strconvTagValue converts a default or example value from the param tag, panicking if it is invalid, if it does not
+// satisfy the param's constraints, or if it cannot be documented, see checkOpenAPIInteger.
+// Slice values are written in the tag as a '|'-separated list, e.g. `default=1|2|3`.
+func (p Param) strconvTagValue(field reflect.StructField, value string) any {
+	strconvFn := func(value string) any {
+		result, err := p.StrconvFn(value)
+		if err == nil {
+			err = p.Validate(value, result)
+		}
+		if err == nil {
+			err = checkOpenAPIInteger(result)
+		}
+		if err != nil {
+			panic("param tag value is invalid: field=" + field.Name + ": " + err.Error())
+		}
+		return result
+	}
+	if !p.Slice {
+		return strconvFn(value)
+	}
+	var values []any
+	for _, v := range strings.Split(value, "|") {
+		values = append(values, strconvFn(v))
+	}
+	return values
 }
@@ -1,11 +1,16 @@
 package field
 
 import (
+	"net/netip"
 	"reflect"
 	"runtime"
 	"testing"
+	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/crunk1/xfuego/internal/types"
 )
 
 func TestParse(t *testing.T) {
@@ -13,6 +18,8 @@ func TestParse(t *testing.T) {
 	pIntT := reflect.TypeOf((*int)(nil))
 	pBoolT := reflect.TypeOf((*bool)(nil))
 	stringT := reflect.TypeOf("")
+	uint32T := reflect.TypeOf(uint32(0))
+	float64T := reflect.TypeOf(0.0)
 
 	// Parse consolidates results from parseTag and parseType, so we only need to
 	// test the Parse-specific logic here: string conversion, implicit-optionality (defaultValue presence),
@@ -22,29 +29,143 @@ func TestParse(t *testing.T) {
 		fieldTag         reflect.StructTag
 		wantIn           In
 		wantRequired     bool
-		wantStrconvFn    func(string) any
+		wantStrconvFn    func(string) (any, error)
 		wantDefaultValue any
 		wantExamples     map[string]any
 	}{
 		{"no tag", intT, "", InNone, false, nil, nil, nil},
-		{"int", intT, `query:""`, InQuery, true, strconvInt, nil, nil},
+		{"int", intT, `query:""`, InQuery, true, strconvInt[int], nil, nil},
 		{"bool optional", pBoolT, `query:""`, InQuery, false, strconvBool, nil, nil},
-		{"int with default (implicit optional)", intT, `query:",,default=1"`, InQuery, false, strconvInt, 1, nil},
-		{"int with default (explicit optional)", pIntT, `query:",,default=1"`, InQuery, false, strconvInt, 1, nil},
+		{"int with default (implicit optional)", intT, `query:",,default=1"`, InQuery, false, strconvInt[int], 1, nil},
+		{"int with default (explicit optional)", pIntT, `query:",,default=1"`, InQuery, false, strconvInt[int], 1, nil},
+		{"uint32 with default", uint32T, `query:",,default=7"`, InQuery, false, strconvUint[uint32], uint32(7), nil},
+		{"float64 with example", float64T, `query:",,example=pi=3.14"`, InQuery, true, strconvFloat[float64], nil, map[string]any{"pi": 3.14}},
+		{"int slice with default", reflect.SliceOf(intT), `query:",,default=1|2"`, InQuery, false, strconvInt[int], []any{1, 2}, nil},
+		{"string slice with example", reflect.SliceOf(stringT), `header:",,example=ex=a|b"`, InHeader, true, strconvString, nil, map[string]any{"ex": []any{"a", "b"}}},
+		{"text with default", reflect.TypeOf(netip.Addr{}), `query:",,default=::1"`, InQuery, false, strconvText(reflect.TypeOf(netip.Addr{})), netip.MustParseAddr("::1"), nil},
 		{"string with example", stringT, `query:",,example=foo=bar"`, InQuery, true, strconvString, nil, map[string]any{"foo": "bar"}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			a := assert.New(t)
 			field := reflect.StructField{Type: tt.fieldType, Tag: tt.fieldTag}
-			gotIn, _, gotRequired, _, gotStrconvFn, _, _, gotDefaultValue, gotExamples := Parse(field)
-			a.Equalf(tt.wantIn, gotIn, "Parse(%v)", field)
-			a.Equalf(tt.wantRequired, gotRequired, "Parse(%v)", field)
+			got := Parse(field)
+			a.Equalf(tt.wantIn, got.In, "Parse(%v)", field)
+			a.Equalf(tt.wantRequired, got.Required, "Parse(%v)", field)
 			f1 := runtime.FuncForPC(reflect.ValueOf(tt.wantStrconvFn).Pointer()).Name()
-			f2 := runtime.FuncForPC(reflect.ValueOf(gotStrconvFn).Pointer()).Name()
+			f2 := runtime.FuncForPC(reflect.ValueOf(got.StrconvFn).Pointer()).Name()
 			a.Equalf(f1, f2, "Parse(%v)", field)
-			a.Equalf(tt.wantDefaultValue, gotDefaultValue, "Parse(%v)", field)
-			a.Equalf(tt.wantExamples, gotExamples, "Parse(%v)", field)
+			a.Equalf(tt.wantDefaultValue, got.DefaultValue, "Parse(%v)", field)
+			a.Equalf(tt.wantExamples, got.Examples, "Parse(%v)", field)
+		})
+	}
+}
+
+func Test_parseStyle(t *testing.T) {
+	stringsT := reflect.TypeOf([]string{})
+	tests := []struct {
+		name          string
+		fieldType     reflect.Type
+		fieldTag      reflect.StructTag
+		wantStyle     string
+		wantExplode   *bool
+		wantDelimiter string
+		wantPanic     bool
+	}{
+		{"non-slice", reflect.TypeOf(""), `query:""`, "", nil, "", false},
+		{"query default", stringsT, `query:""`, StyleForm, lo.ToPtr(true), "", false},
+		{"query form unexploded", stringsT, `query:",,explode=false"`, StyleForm, lo.ToPtr(false), ",", false},
+		{"query spaceDelimited", stringsT, `query:",,style=spaceDelimited,explode=false"`, StyleSpaceDelimited, lo.ToPtr(false), " ", false},
+		{"query pipeDelimited", stringsT, `query:",,style=pipeDelimited,explode=false"`, StylePipeDelimited, lo.ToPtr(false), "|", false},
+		{"query pipeDelimited default", stringsT, `query:",,style=pipeDelimited"`, StylePipeDelimited, lo.ToPtr(false), "|", false},
+		{"query spaceDelimited default", stringsT, `query:",,style=spaceDelimited"`, StyleSpaceDelimited, lo.ToPtr(false), " ", false},
+		{"header default", stringsT, `header:""`, StyleSimple, lo.ToPtr(false), ",", false},
+		{"cookie default", stringsT, `cookie:""`, StyleForm, lo.ToPtr(false), ",", false},
+		{"form default", stringsT, `form:""`, StyleForm, lo.ToPtr(true), "", false},
+		{"form pipeDelimited", stringsT, `form:",,style=pipeDelimited"`, StylePipeDelimited, lo.ToPtr(false), "|", false},
+		{"panic on non-slice style", reflect.TypeOf(""), `query:",,style=form"`, "", nil, "", true},
+		{"panic on bad query style", stringsT, `query:",,style=simple"`, "", nil, "", true},
+		{"panic on bad header style", stringsT, `header:",,style=form"`, "", nil, "", true},
+		{"panic on bad form style", stringsT, `form:",,style=simple"`, "", nil, "", true},
+		{"panic on exploded cookie", stringsT, `cookie:",,explode=true"`, "", nil, "", true},
+		{"panic on exploded pipeDelimited", stringsT, `query:",,style=pipeDelimited,explode=true"`, "", nil, "", true},
+		{"panic on exploded form spaceDelimited", stringsT, `form:",,style=spaceDelimited,explode=true"`, "", nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			field := reflect.StructField{Name: "X", Type: tt.fieldType, Tag: tt.fieldTag}
+			if tt.wantPanic {
+				a.Panics(func() { Parse(field) })
+				return
+			}
+			got := Parse(field)
+			a.Equal(tt.wantStyle, got.Style)
+			a.Equal(tt.wantExplode, got.Explode)
+			a.Equal(tt.wantDelimiter, got.Delimiter())
+		})
+	}
+}
+
+func TestParse_time(t *testing.T) {
+	timeT := reflect.TypeOf(time.Time{})
+	tests := []struct {
+		name             string
+		fieldType        reflect.Type
+		fieldTag         reflect.StructTag
+		wantDefaultValue any
+		wantPanic        bool
+	}{
+		{"time.Time RFC 3339", timeT, `query:",,default=2025-01-01T10:00:00Z"`, time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), false},
+		{"time.Time layout", timeT, `query:",,default=2025-01-01 10:00,layout=2006-01-02 15:04"`, time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), false},
+		{"Date", reflect.TypeOf(types.Date{}), `query:",,default=2025-01-01"`, types.Date{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}, false},
+		{"Date layout", reflect.TypeOf(types.Date{}), `query:",,default=01/02/2025,layout=01/02/2006"`, types.Date{Time: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)}, false},
+		{"time.Duration", reflect.TypeOf(time.Duration(0)), `query:",,default=1m30s"`, 90 * time.Second, false},
+		{"panic on bad duration", reflect.TypeOf(time.Duration(0)), `query:",,default=90"`, nil, true},
+		{"panic on layout mismatch", timeT, `query:",,default=2025-01-01,layout=2006-01-02 15:04"`, nil, true},
+		{"panic on layout for non-time", reflect.TypeOf(""), `query:",,layout=2006"`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			field := reflect.StructField{Name: "X", Type: tt.fieldType, Tag: tt.fieldTag}
+			if tt.wantPanic {
+				a.Panics(func() { Parse(field) })
+				return
+			}
+			got := Parse(field)
+			a.True(got.Text)
+			a.Equal(tt.wantDefaultValue, got.DefaultValue)
+		})
+	}
+}
+
+func TestParse_map(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldType reflect.Type
+		fieldTag  reflect.StructTag
+		wantPanic bool
+	}{
+		{"query map", reflect.TypeOf(map[string]string{}), `query:"label."`, false},
+		{"header map with constraints", reflect.TypeOf(map[string]int{}), `header:"X-Meta-,,min=1"`, false},
+		{"panic on path map", reflect.TypeOf(map[string]string{}), `path:"id"`, true},
+		{"panic on form map", reflect.TypeOf(map[string]string{}), `form:"label."`, true},
+		{"panic on map default", reflect.TypeOf(map[string]string{}), `query:"label.,,default=x"`, true},
+		{"panic on map style", reflect.TypeOf(map[string]string{}), `query:"label.,,style=form"`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			field := reflect.StructField{Name: "X", Type: tt.fieldType, Tag: tt.fieldTag}
+			if tt.wantPanic {
+				a.Panics(func() { Parse(field) })
+				return
+			}
+			got := Parse(field)
+			a.True(got.Map)
+			a.False(got.Required)
+			a.Equal(tt.fieldType.Elem(), got.GoType)
 		})
 	}
 }
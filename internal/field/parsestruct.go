@@ -0,0 +1,141 @@
+package field
+
+import (
+	"fmt"
+	"net/textproto"
+	"reflect"
+	"strings"
+)
+
+// Embedding is an embedded struct field that a promoted param field is reached through, or a nested struct param field.
+type Embedding struct {
+	Offset uintptr      // Offset of the embedded field in its parent struct.
+	Type   reflect.Type // Type of the embedded struct, dereferenced for pointer embeddings.
+	Ptr    bool         // Set for pointer embeddings, e.g. `*Pagination`.
+}
+
+// ParseStruct parses the param fields of a params struct, including the fields promoted from its embedded structs
+// (and pointer-to-struct embeddings), e.g. a `Pagination` struct shared by many params structs, and the fields of its
+// nested struct params, see Object.
+// Params must have unique names and aliases across the flattened set, per location; header names are case-insensitive.
+// ParseStruct panics if any field is invalid, see TryParseStruct.
+func ParseStruct(t reflect.Type) []Param {
+	params, err := TryParseStruct(t)
+	if err != nil {
+		panic(err.Error())
+	}
+	return params
+}
+
+// TryParseStruct is ParseStruct, returning a *StructError listing every invalid field instead of panicking. The params
+// of the valid fields are returned along with the error.
+func TryParseStruct(t reflect.Type) (params []Param, err error) {
+	var errs []string
+	names := map[In]map[string]string{} // in -> name -> field name
+	for _, p := range parseStruct(t, nil, &errs) {
+		if names[p.In] == nil {
+			names[p.In] = map[string]string{}
+		}
+		duplicate := false
+		for _, paramName := range append([]string{p.Name}, p.Aliases...) {
+			name := paramName
+			if p.In == InHeader {
+				name = textproto.CanonicalMIMEHeaderKey(name)
+			}
+			if fieldName, ok := names[p.In][name]; ok {
+				errs = append(errs, "duplicate "+p.In.String()+" param name "+paramName+": fields="+fieldName+","+p.Field.Name)
+				duplicate = true
+				continue
+			}
+			names[p.In][name] = p.Field.Name
+		}
+		if !duplicate {
+			params = append(params, p)
+		}
+	}
+	if len(errs) > 0 {
+		return params, &StructError{Type: t, Errors: errs}
+	}
+	return params, nil
+}
+
+// StructError lists every invalid field of a params struct.
+type StructError struct {
+	Type   reflect.Type
+	Errors []string
+}
+
+func (e *StructError) Error() string {
+	return "invalid params struct " + e.Type.String() + ": " + strings.Join(e.Errors, "; ")
+}
+
+// parseStruct parses the param fields of t, appending the panic message of each invalid field to errs.
+func parseStruct(t reflect.Type, embeddings []Embedding, errs *[]string) (params []Param) {
+	for i := 0; i < t.NumField(); i++ {
+		params = append(params, parseStructField(t, t.Field(i), embeddings, errs)...)
+	}
+	return params
+}
+
+// parseStructField parses a field of the struct t. Params without a description in their tag are described by the
+// field's registered doc comment, see RegisterDocs.
+func parseStructField(t reflect.Type, f reflect.StructField, embeddings []Embedding, errs *[]string) (params []Param) {
+	defer func() {
+		if r := recover(); r != nil {
+			*errs = append(*errs, fmt.Sprint(r))
+			params = nil
+		}
+	}()
+	if embedded, ptr, ok := embeddedStruct(f); ok {
+		embedding := Embedding{Offset: f.Offset, Type: embedded, Ptr: ptr}
+		embeddedParams := parseStruct(embedded, append(embeddings[:len(embeddings):len(embeddings)], embedding), errs)
+		if len(embeddedParams) > 0 && !f.IsExported() {
+			panic("param embedded struct must be exported: field=" + f.Name)
+		}
+		return embeddedParams
+	}
+	if object := parseObject(f); object != nil {
+		if object.Desc == "" {
+			object.Desc = FieldDoc(t, f.Name)
+		}
+		embedding := Embedding{Offset: f.Offset, Type: f.Type}
+		objectParams := parseStruct(f.Type, append(embeddings[:len(embeddings):len(embeddings)], embedding), errs)
+		for i := range objectParams {
+			op := objectParams[i]
+			if op.In != InQuery || op.Object != nil || op.Slice || op.Map {
+				panic("param nested struct fields must be scalar query params: field=" + f.Name + "." + op.Field.Name)
+			}
+			if op.Aliases != nil {
+				panic("param opt 'alias' is not supported for nested struct params: field=" + f.Name + "." + op.Field.Name)
+			}
+			objectParams[i].Object = object
+			objectParams[i].Name = object.ParamName(objectParams[i].Name)
+		}
+		return objectParams
+	}
+	p := Parse(f)
+	if p.In == InNone {
+		return nil
+	}
+	p.Embeddings = embeddings
+	if p.Desc == "" {
+		p.Desc = FieldDoc(t, f.Name)
+	}
+	return []Param{p}
+}
+
+// embeddedStruct returns the struct type of an embedded struct or pointer-to-struct field without a param tag.
+// Embedded text types, e.g. `OrderID` with a `query:"id"` tag, are regular param fields.
+func embeddedStruct(f reflect.StructField) (t reflect.Type, ptr bool, ok bool) {
+	if !f.Anonymous || parseTag(f).In != InNone {
+		return nil, false, false
+	}
+	t = f.Type
+	if t.Kind() == reflect.Pointer {
+		t, ptr = t.Elem(), true
+	}
+	if t.Kind() != reflect.Struct || isText(t) {
+		return nil, false, false
+	}
+	return t, ptr, true
+}
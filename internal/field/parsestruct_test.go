@@ -0,0 +1,214 @@
+package field
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Pagination struct {
+	Limit  int `query:"limit,,default=20"`
+	Offset int `query:"offset,,default=0"`
+}
+
+type TenantHeaders struct {
+	Tenant string `header:"X-Tenant"`
+}
+
+type pagination struct {
+	Limit int `query:"limit"`
+}
+
+type OrderID string
+
+func (*OrderID) UnmarshalText([]byte) error { return nil }
+
+func TestParseStruct(t *testing.T) {
+	type Params struct {
+		ID int `path:"id"`
+		Pagination
+		*TenantHeaders
+		Ignored string
+	}
+	a := assert.New(t)
+	got := ParseStruct(reflect.TypeOf(Params{}))
+	a.Equal([]string{"id", "limit", "offset", "X-Tenant"}, []string{got[0].Name, got[1].Name, got[2].Name, got[3].Name})
+	a.Len(got, 4)
+	a.Empty(got[0].Embeddings)
+	a.Equal([]Embedding{{Offset: reflect.TypeOf(Params{}).Field(1).Offset, Type: reflect.TypeOf(Pagination{})}}, got[2].Embeddings)
+	a.Equal(reflect.TypeOf(Pagination{}).Field(1).Offset, got[2].Field.Offset)
+	a.Equal([]Embedding{{Offset: reflect.TypeOf(Params{}).Field(2).Offset, Type: reflect.TypeOf(TenantHeaders{}), Ptr: true}}, got[3].Embeddings)
+}
+
+func TestParseStruct_panics(t *testing.T) {
+	tests := []struct {
+		name      string
+		paramsT   reflect.Type
+		wantPanic bool
+	}{
+		{"embedded text type is a param", reflect.TypeOf(struct {
+			OrderID `query:"id"`
+		}{}), false},
+		{"unexported embedded struct without params", reflect.TypeOf(struct {
+			unexported struct{ X int }
+		}{}), false},
+		{"duplicate across embedded struct", reflect.TypeOf(struct {
+			Limit int `query:"limit"`
+			Pagination
+		}{}), true},
+		{"duplicate header names are case-insensitive", reflect.TypeOf(struct {
+			Tenant string `header:"x-tenant"`
+			TenantHeaders
+		}{}), true},
+		{"same name in different locations", reflect.TypeOf(struct {
+			Limit int `header:"limit"`
+			Pagination
+		}{}), false},
+		{"unexported embedded struct with params", reflect.TypeOf(struct {
+			pagination
+		}{}), true},
+		{"alias duplicates a param name", reflect.TypeOf(struct {
+			Size int `query:"size,,alias=limit"`
+			Pagination
+		}{}), true},
+		{"alias of a path param", reflect.TypeOf(struct {
+			ID int `path:"id,,alias=uid"`
+		}{}), true},
+		{"alias of a nested struct param", reflect.TypeOf(struct {
+			Filter struct {
+				Status string `query:"status,,alias=state"`
+			} `query:"filter"`
+		}{}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			if tt.wantPanic {
+				a.Panics(func() { ParseStruct(tt.paramsT) })
+				return
+			}
+			a.NotPanics(func() { ParseStruct(tt.paramsT) })
+		})
+	}
+}
+
+type FilterParams struct {
+	Status string  `query:"status"`
+	Owner  *string `query:"owner"`
+}
+
+func TestParseStruct_objects(t *testing.T) {
+	type Params struct {
+		Filter FilterParams `query:"filter,Filters"`
+		Dotted FilterParams `query:",,separator=."`
+	}
+	a := assert.New(t)
+	got := ParseStruct(reflect.TypeOf(Params{}))
+	a.Equal([]string{"filter[status]", "filter[owner]", "Dotted.status", "Dotted.owner"}, []string{got[0].Name, got[1].Name, got[2].Name, got[3].Name})
+	a.Len(got, 4)
+	a.Equal(&Object{Name: "filter", Desc: "Filters"}, got[0].Object)
+	a.Same(got[0].Object, got[1].Object)
+	a.Equal(&Object{Name: "Dotted", Separator: "."}, got[2].Object)
+	a.Equal([]Embedding{{Offset: reflect.TypeOf(Params{}).Field(1).Offset, Type: reflect.TypeOf(FilterParams{})}}, got[3].Embeddings)
+	a.Equal("owner", got[1].Object.PropertyName(got[1].Name))
+	a.Equal("owner", got[3].Object.PropertyName(got[3].Name))
+}
+
+func TestParseStruct_objectPanics(t *testing.T) {
+	tests := []struct {
+		name    string
+		paramsT reflect.Type
+	}{
+		{"pointer", reflect.TypeOf(struct {
+			Filter *FilterParams `query:"filter"`
+		}{})},
+		{"header", reflect.TypeOf(struct {
+			Filter FilterParams `header:"filter"`
+		}{})},
+		{"non-query nested param", reflect.TypeOf(struct {
+			Filter struct {
+				Tenant string `header:"X-Tenant"`
+			} `query:"filter"`
+		}{})},
+		{"nested object", reflect.TypeOf(struct {
+			Filter struct {
+				Inner FilterParams `query:"inner"`
+			} `query:"filter"`
+		}{})},
+		{"slice nested param", reflect.TypeOf(struct {
+			Filter struct {
+				IDs []int `query:"ids"`
+			} `query:"filter"`
+		}{})},
+		{"unsupported style", reflect.TypeOf(struct {
+			Filter FilterParams `query:"filter,,style=form"`
+		}{})},
+		{"style and separator", reflect.TypeOf(struct {
+			Filter FilterParams `query:"filter,,style=deepObject,separator=."`
+		}{})},
+		{"default", reflect.TypeOf(struct {
+			Filter FilterParams `query:"filter,,default=x"`
+		}{})},
+		{"separator on non-struct", reflect.TypeOf(struct {
+			Filter string `query:"filter,,separator=."`
+		}{})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Panics(t, func() { ParseStruct(tt.paramsT) })
+		})
+	}
+}
+
+func TestTryParseStruct(t *testing.T) {
+	type Params struct {
+		A int        `query:",,default=x"`
+		b int        `query:"b"`
+		C complex128 `query:"c"`
+		Pagination
+		Limit int `query:"limit"`
+	}
+	a := assert.New(t)
+	got, err := TryParseStruct(reflect.TypeOf(Params{}))
+	a.Equal([]string{"limit", "offset"}, []string{got[0].Name, got[1].Name}) // the valid params
+	a.Len(got, 2)
+	a.Equal(&StructError{Type: reflect.TypeOf(Params{}), Errors: []string{
+		`param tag value is invalid: field=A: value is not a valid int: "x"`,
+		"param field must be exported: field=b",
+		"param field base type must be a bool|number|string|encoding.TextUnmarshaler or a slice of them: field=C",
+		"duplicate query param name limit: fields=Limit,Limit",
+	}}, err)
+	a.PanicsWithValue(err.Error(), func() { ParseStruct(reflect.TypeOf(Params{})) })
+
+	got, err = TryParseStruct(reflect.TypeOf(struct{ Pagination }{}))
+	a.NoError(err)
+	a.Len(got, 2)
+}
+
+func TestParseStruct_docs(t *testing.T) {
+	type Embedded struct {
+		Offset int `query:"offset"`
+	}
+	type Params struct {
+		Limit  int          `query:"limit"`
+		Sort   string       `query:"sort,Sort order"`
+		Filter FilterParams `query:"filter"`
+		Embedded
+	}
+	RegisterDocs(reflect.TypeOf(Params{}), map[string]string{
+		"Limit":  "Max items.\n\nCapped at **100**.",
+		"Sort":   "Ignored, the tag has a description.",
+		"Filter": "Filters.",
+		"Offset": "Ignored, Offset is a field of Embedded.",
+	})
+	RegisterDocs(reflect.TypeOf(Embedded{}), map[string]string{"Offset": "Items to skip."})
+	a := assert.New(t)
+	got := ParseStruct(reflect.TypeOf(Params{}))
+	a.Len(got, 5)
+	a.Equal("Max items.\n\nCapped at **100**.", got[0].Desc)
+	a.Equal("Sort order", got[1].Desc)
+	a.Equal("Filters.", got[2].Object.Desc)
+	a.Equal("", got[2].Desc)
+	a.Equal("Items to skip.", got[4].Desc)
+}
@@ -2,16 +2,22 @@ package field
 
 import (
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
-// parseTag parses the struct tag for a parameter and returns the location and tag value.
-// It supports the following tags: query, path, header, and cookie.
+// parseTag parses the struct tag for a parameter and returns the location and tag value components.
+// It supports the following tags: query, path, header, cookie, and form.
 //
 // A valid tag value is of the form:
 // "name,description,default=foo,example=exampleName=foo,example=exampleName2=bar"
-func parseTag(field reflect.StructField) (in In, name string, desc string, defaultValue any, examples map[string]any) {
+//
+// The description, default and an example may also be set with their own tags, which are taken verbatim:
+// desc:"Max items, capped at 100" default:"a,b" example:"exampleName=foo".
+func parseTag(field reflect.StructField) (p Param) {
 	matches := 0
+	var in In
 	var tagValue string
 	tag := field.Tag
 	if queryTag, ok := tag.Lookup("query"); ok {
@@ -34,10 +40,44 @@ func parseTag(field reflect.StructField) (in In, name string, desc string, defau
 		tagValue = cookieTag
 		matches++
 	}
+	if formTag, ok := tag.Lookup("form"); ok {
+		in = InForm
+		tagValue = formTag
+		matches++
+	}
 	if matches > 1 {
 		panic("param field cannot have more than one param tag: field=" + field.Name)
 	}
-	name, desc, defaultValue, examples = parseTagValue(tagValue)
+	p = parseTagValue(tagValue)
+	p.In = in
+	if in == InNone {
+		return
+	}
+	if desc, ok := tag.Lookup("desc"); ok {
+		if p.Desc != "" {
+			panic("param description cannot be set in both the " + in.String() + " and desc tags: field=" + field.Name)
+		}
+		p.Desc = desc
+	}
+	if defaultValue, ok := tag.Lookup("default"); ok {
+		if p.DefaultValue != nil {
+			panic("param default cannot be set in both the " + in.String() + " and default tags: field=" + field.Name)
+		}
+		p.DefaultValue = defaultValue
+	}
+	if example, ok := tag.Lookup("example"); ok {
+		exampleName, exampleValue, found := strings.Cut(example, "=")
+		if !found {
+			panic("param example tag must be a 'key=value' string: field=" + field.Name)
+		}
+		if _, ok := p.Examples[exampleName]; ok {
+			panic("param example '" + exampleName + "' cannot be set in both the " + in.String() + " and example tags: field=" + field.Name)
+		}
+		if p.Examples == nil {
+			p.Examples = make(map[string]any)
+		}
+		p.Examples[exampleName] = exampleValue
+	}
 	return
 }
 
@@ -45,28 +85,30 @@ func parseTag(field reflect.StructField) (in In, name string, desc string, defau
 //
 // A valid tag value is of the form:
 //
-//	"name,description,default=foo,example=exampleName=foo,example=exampleName2=bar"
-func parseTagValue(tagValue string) (name, desc string, defaultValue any, examples map[string]any) {
-	parts := strings.Split(tagValue, ",")
+//	"name,description,default=foo,example=exampleName=foo,example=exampleName2=bar,style=pipeDelimited,explode=false,layout=2006-01-02,min=1,max=100,enum=1|10|100,alias=old_name,deprecated"
+//
+// See splitTagValue for quoting descriptions and values containing commas.
+func parseTagValue(tagValue string) (p Param) {
+	parts := splitTagValue(tagValue)
 	if len(parts) >= 1 {
-		name = parts[0]
+		p.Name = parts[0]
 	}
 	if len(parts) >= 2 {
-		desc = parts[1]
+		p.Desc = parts[1]
 	}
 	if len(parts) < 3 {
-		return name, desc, nil, nil
+		return p
 	}
 	parts = parts[2:]
 
-	// param opts: default, example, required
+	// param opts: default, example, style, explode, layout, separator, min, max, multipleOf, minLength, maxLength, pattern, alias, deprecated, enum
 	for _, part := range parts {
 		optParts := strings.SplitN(part, "=", 2)
 		if optParts[0] == "default" {
 			if len(optParts) == 1 {
 				panic("param opt 'default' must have a value, param opts: " + tagValue)
 			}
-			defaultValue = optParts[1]
+			p.DefaultValue = optParts[1]
 		} else if optParts[0] == "example" {
 			if len(optParts) == 1 {
 				panic("param opt 'example' must have a value, param opts: " + tagValue)
@@ -75,14 +117,151 @@ func parseTagValue(tagValue string) (name, desc string, defaultValue any, exampl
 			if len(exampleParts) == 1 {
 				panic("param opt 'example' must be a 'key=value' string, param opts: " + tagValue)
 			}
-			if examples == nil {
-				examples = make(map[string]any)
+			if p.Examples == nil {
+				p.Examples = make(map[string]any)
+			}
+			p.Examples[exampleParts[0]] = exampleParts[1]
+		} else if optParts[0] == "style" {
+			if len(optParts) == 1 {
+				panic("param opt 'style' must have a value, param opts: " + tagValue)
+			}
+			p.Style = optParts[1]
+		} else if optParts[0] == "layout" {
+			if len(optParts) == 1 {
+				panic("param opt 'layout' must have a value, param opts: " + tagValue)
+			}
+			p.Layout = optParts[1]
+		} else if optParts[0] == "separator" {
+			if len(optParts) == 1 || optParts[1] == "" {
+				panic("param opt 'separator' must have a value, param opts: " + tagValue)
+			}
+			p.Separator = optParts[1]
+		} else if optParts[0] == "explode" {
+			if len(optParts) == 1 {
+				panic("param opt 'explode' must have a value, param opts: " + tagValue)
+			}
+			explode, err := strconv.ParseBool(optParts[1])
+			if err != nil {
+				panic("param opt 'explode' must be a bool, param opts: " + tagValue)
+			}
+			p.Explode = &explode
+		} else if optParts[0] == "min" || optParts[0] == "max" || optParts[0] == "multipleOf" {
+			if len(optParts) == 1 {
+				panic("param opt '" + optParts[0] + "' must have a value, param opts: " + tagValue)
+			}
+			f, err := strconv.ParseFloat(optParts[1], 64)
+			if err != nil {
+				panic("param opt '" + optParts[0] + "' must be a number, param opts: " + tagValue)
+			}
+			if optParts[0] == "min" {
+				p.Min = &f
+			} else if optParts[0] == "max" {
+				p.Max = &f
+			} else if f <= 0 {
+				panic("param opt 'multipleOf' must be greater than 0, param opts: " + tagValue)
+			} else {
+				p.MultipleOf = &f
+			}
+		} else if optParts[0] == "minLength" || optParts[0] == "maxLength" {
+			if len(optParts) == 1 {
+				panic("param opt '" + optParts[0] + "' must have a value, param opts: " + tagValue)
+			}
+			n, err := strconv.ParseUint(optParts[1], 10, 64)
+			if err != nil {
+				panic("param opt '" + optParts[0] + "' must be a non-negative integer, param opts: " + tagValue)
+			}
+			if optParts[0] == "minLength" {
+				p.MinLength = &n
+			} else {
+				p.MaxLength = &n
+			}
+		} else if optParts[0] == "pattern" {
+			if len(optParts) == 1 {
+				panic("param opt 'pattern' must have a value, param opts: " + tagValue)
+			}
+			pattern, err := regexp.Compile(optParts[1])
+			if err != nil {
+				panic("param opt 'pattern' must be a valid regular expression, param opts: " + tagValue)
+			}
+			p.Pattern = pattern
+		} else if optParts[0] == "alias" {
+			if len(optParts) == 1 || optParts[1] == "" {
+				panic("param opt 'alias' must have a value, param opts: " + tagValue)
+			}
+			p.Aliases = append(p.Aliases, strings.Split(optParts[1], "|")...)
+		} else if optParts[0] == "deprecated" {
+			if len(optParts) == 2 {
+				panic("param opt 'deprecated' takes no value, param opts: " + tagValue)
+			}
+			p.Deprecated = true
+		} else if optParts[0] == "enum" {
+			if len(optParts) == 1 {
+				panic("param opt 'enum' must have a value, param opts: " + tagValue)
+			}
+			for _, enumValue := range strings.Split(optParts[1], "|") {
+				p.Enum = append(p.Enum, enumValue)
 			}
-			examples[exampleParts[0]] = exampleParts[1]
 		} else {
-			panic("unknown param opt '" + optParts[0] + "', param opts: " + tagValue)
+			panic("unknown param opt '" + optParts[0] + "' (quote descriptions and values containing commas, e.g. 'a, b'), param opts: " + tagValue)
+		}
+	}
+
+	return p
+}
+
+// splitTagValue splits a param tag value on commas. The description and option values may be single-quoted to contain
+// commas, e.g. 'Max items, capped at 100' or default='a,b'; a quote within a quoted value is written twice. Quoted
+// values are returned unquoted. The name cannot be quoted.
+func splitTagValue(tagValue string) (parts []string) {
+	rest := tagValue
+	for {
+		var prefix string
+		if len(parts) >= 2 {
+			// Option -> the value starts after the first '='
+			if i := strings.IndexAny(rest, ",="); i >= 0 && rest[i] == '=' {
+				prefix, rest = rest[:i+1], rest[i+1:]
+			}
 		}
+		if len(parts) >= 1 && strings.HasPrefix(rest, "'") {
+			value, after, ok := unquoteTagValue(rest)
+			if !ok {
+				panic("param tag value has an unterminated quote, param opts: " + tagValue)
+			}
+			if after != "" && after[0] != ',' {
+				panic("param tag quoted value must be followed by a comma or the end of the tag, param opts: " + tagValue)
+			}
+			parts = append(parts, prefix+value)
+			if after == "" {
+				return parts
+			}
+			rest = after[1:]
+			continue
+		}
+		part, after, found := strings.Cut(rest, ",")
+		parts = append(parts, prefix+part)
+		if !found {
+			return parts
+		}
+		rest = after
 	}
+}
 
-	return name, desc, defaultValue, examples
+// unquoteTagValue unquotes the single-quoted value at the start of s, and returns what follows it. ok is false if the
+// value is not terminated.
+func unquoteTagValue(s string) (value, rest string, ok bool) {
+	var b strings.Builder
+	s = s[1:]
+	for {
+		i := strings.IndexByte(s, '\'')
+		if i < 0 {
+			return "", "", false
+		}
+		b.WriteString(s[:i])
+		s = s[i+1:]
+		if !strings.HasPrefix(s, "'") {
+			return b.String(), s, true
+		}
+		b.WriteByte('\'')
+		s = s[1:]
+	}
 }
@@ -2,8 +2,10 @@ package field
 
 import (
 	"reflect"
+	"regexp"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,7 +27,9 @@ func Test_Parse(t *testing.T) {
 		{"path tag", `path:"foo"`, InPath, false},
 		{"header tag", `header:"foo"`, InHeader, false},
 		{"cookie tag", `cookie:"foo"`, InCookie, false},
+		{"form tag", `form:"foo"`, InForm, false},
 		{"panic on multiple tags", `query:"foo" path:"bar"`, 0, true},
+		{"panic on query and form tags", `query:"foo" form:"foo"`, 0, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -35,9 +39,9 @@ func Test_Parse(t *testing.T) {
 				a.Panics(func() { parseTag(field) })
 				return
 			}
-			gotIn, _, _, _, _ := parseTag(field)
-			if gotIn != tt.wantIn {
-				t.Errorf("gotIn %v, wantIn %v", gotIn, tt.wantIn)
+			got := parseTag(field)
+			if got.In != tt.wantIn {
+				t.Errorf("gotIn %v, wantIn %v", got.In, tt.wantIn)
 			}
 		})
 	}
@@ -51,22 +55,165 @@ func Test_parseTagValue(t *testing.T) {
 		wantDesc         string
 		wantDefaultValue any
 		wantExamples     map[string]any
+		wantStyle        string
+		wantExplode      *bool
+		wantPanic        bool
 	}{
-		{"name only", "name", "name", "", nil, nil},
-		{"name and desc", "name,description", "name", "description", nil, nil},
-		{"desc but no name", ",description", "", "description", nil, nil},
-		{"name and default", "name,,default=foo", "name", "", "foo", nil},
-		{"example", ",,example=exampleName=foo", "", "", nil, map[string]any{"exampleName": "foo"}},
-		{"example,default,example", ",,example=exampleName=foo,default=bar,example=exampleName2=foo2", "", "", "bar", map[string]any{"exampleName": "foo", "exampleName2": "foo2"}},
+		{"name only", "name", "name", "", nil, nil, "", nil, false},
+		{"name and desc", "name,description", "name", "description", nil, nil, "", nil, false},
+		{"desc but no name", ",description", "", "description", nil, nil, "", nil, false},
+		{"name and default", "name,,default=foo", "name", "", "foo", nil, "", nil, false},
+		{"example", ",,example=exampleName=foo", "", "", nil, map[string]any{"exampleName": "foo"}, "", nil, false},
+		{"example,default,example", ",,example=exampleName=foo,default=bar,example=exampleName2=foo2", "", "", "bar", map[string]any{"exampleName": "foo", "exampleName2": "foo2"}, "", nil, false},
+		{"style and explode", ",,style=pipeDelimited,explode=false", "", "", nil, nil, "pipeDelimited", lo.ToPtr(false), false},
+		{"panic on non-bool explode", ",,explode=maybe", "", "", nil, nil, "", nil, true},
+		{"panic on unknown opt", ",,foo=bar", "", "", nil, nil, "", nil, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			a := assert.New(t)
-			gotName, gotDesc, gotDefaultValue, gotExamples := parseTagValue(tt.argTagValue)
-			a.Equal(tt.wantName, gotName)
-			a.Equal(tt.wantDesc, gotDesc)
-			a.Equal(tt.wantDefaultValue, gotDefaultValue)
-			a.Equal(tt.wantExamples, gotExamples)
+			if tt.wantPanic {
+				a.Panics(func() { parseTagValue(tt.argTagValue) })
+				return
+			}
+			got := parseTagValue(tt.argTagValue)
+			a.Equal(tt.wantName, got.Name)
+			a.Equal(tt.wantDesc, got.Desc)
+			a.Equal(tt.wantDefaultValue, got.DefaultValue)
+			a.Equal(tt.wantExamples, got.Examples)
+			a.Equal(tt.wantStyle, got.Style)
+			a.Equal(tt.wantExplode, got.Explode)
+		})
+	}
+}
+
+func Test_parseTagValue_constraints(t *testing.T) {
+	tests := []struct {
+		name        string
+		argTagValue string
+		want        Param
+		wantPanic   bool
+	}{
+		{"min and max", ",,min=1,max=100.5", Param{Min: lo.ToPtr(1.0), Max: lo.ToPtr(100.5)}, false},
+		{"multipleOf", ",,multipleOf=0.5", Param{MultipleOf: lo.ToPtr(0.5)}, false},
+		{"lengths", ",,minLength=1,maxLength=10", Param{MinLength: lo.ToPtr[uint64](1), MaxLength: lo.ToPtr[uint64](10)}, false},
+		{"enum", ",,enum=asc|desc", Param{Enum: []any{"asc", "desc"}}, false},
+		{"panic on non-number min", ",,min=one", Param{}, true},
+		{"panic on zero multipleOf", ",,multipleOf=0", Param{}, true},
+		{"panic on negative minLength", ",,minLength=-1", Param{}, true},
+		{"panic on invalid pattern", ",,pattern=[a-", Param{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			if tt.wantPanic {
+				a.Panics(func() { parseTagValue(tt.argTagValue) })
+				return
+			}
+			got := parseTagValue(tt.argTagValue)
+			a.Equal(tt.want.Min, got.Min)
+			a.Equal(tt.want.Max, got.Max)
+			a.Equal(tt.want.MultipleOf, got.MultipleOf)
+			a.Equal(tt.want.MinLength, got.MinLength)
+			a.Equal(tt.want.MaxLength, got.MaxLength)
+			a.Equal(tt.want.Enum, got.Enum)
+		})
+	}
+}
+
+func Test_parseTagValue_aliases(t *testing.T) {
+	tests := []struct {
+		name        string
+		argTagValue string
+		want        Param
+		wantPanic   bool
+	}{
+		{"aliases", "limit,,alias=page_size|pageSize", Param{Name: "limit", Aliases: []string{"page_size", "pageSize"}}, false},
+		{"repeated alias", "limit,,alias=page_size,alias=size", Param{Name: "limit", Aliases: []string{"page_size", "size"}}, false},
+		{"deprecated", "limit,,deprecated", Param{Name: "limit", Deprecated: true}, false},
+		{"panic on empty alias", "limit,,alias=", Param{}, true},
+		{"panic on deprecated with a value", "limit,,deprecated=true", Param{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			if tt.wantPanic {
+				a.Panics(func() { parseTagValue(tt.argTagValue) })
+				return
+			}
+			got := parseTagValue(tt.argTagValue)
+			a.Equal(tt.want.Name, got.Name)
+			a.Equal(tt.want.Aliases, got.Aliases)
+			a.Equal(tt.want.Deprecated, got.Deprecated)
+		})
+	}
+}
+
+func Test_parseTagValue_quoting(t *testing.T) {
+	tests := []struct {
+		name        string
+		argTagValue string
+		want        Param
+		wantPanic   bool
+	}{
+		{"quoted desc", "limit,'Max items, capped at 100',default=20", Param{Name: "limit", Desc: "Max items, capped at 100", DefaultValue: "20"}, false},
+		{"quoted default", "tags,,default='a,b'", Param{Name: "tags", DefaultValue: "a,b"}, false},
+		{"quoted example", ",,example='first=a,b'", Param{Examples: map[string]any{"first": "a,b"}}, false},
+		{"doubled quote", "id,'the user''s id'", Param{Name: "id", Desc: "the user's id"}, false},
+		{"empty quoted desc", "id,'',default=1", Param{Name: "id", DefaultValue: "1"}, false},
+		{"quote within unquoted value", "id,the user's id,pattern=^a'b$", Param{Name: "id", Desc: "the user's id", Pattern: regexp.MustCompile("^a'b$")}, false},
+		{"equals sign in value", "filter,,default=a=b", Param{Name: "filter", DefaultValue: "a=b"}, false},
+		{"panic on unterminated quote", "limit,'Max items, capped at 100", Param{}, true},
+		{"panic on text after quote", "limit,'Max items' capped", Param{}, true},
+		{"panic on unquoted comma", "limit,Max items, capped at 100", Param{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			if tt.wantPanic {
+				a.Panics(func() { parseTagValue(tt.argTagValue) })
+				return
+			}
+			got := parseTagValue(tt.argTagValue)
+			a.Equal(tt.want.Name, got.Name)
+			a.Equal(tt.want.Desc, got.Desc)
+			a.Equal(tt.want.DefaultValue, got.DefaultValue)
+			a.Equal(tt.want.Examples, got.Examples)
+			a.Equal(tt.want.Pattern, got.Pattern)
+		})
+	}
+}
+
+func Test_parseTag_optTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		argTag    reflect.StructTag
+		want      Param
+		wantPanic bool
+	}{
+		{"desc tag", `query:"limit" desc:"Max items, capped at 100"`, Param{In: InQuery, Name: "limit", Desc: "Max items, capped at 100"}, false},
+		{"default tag", `query:"tags" default:"a,b"`, Param{In: InQuery, Name: "tags", DefaultValue: "a,b"}, false},
+		{"example tag", `query:"tags,,example=one=a" example:"two=a,b"`, Param{In: InQuery, Name: "tags", Examples: map[string]any{"one": "a", "two": "a,b"}}, false},
+		{"opt tags without param tag", `json:"tags" desc:"Tags" default:"a"`, Param{}, false},
+		{"panic on desc in both tags", `query:"limit,Max items" desc:"Max items"`, Param{}, true},
+		{"panic on default in both tags", `query:"limit,,default=1" default:"1"`, Param{}, true},
+		{"panic on example in both tags", `query:"tags,,example=one=a" example:"one=b"`, Param{}, true},
+		{"panic on example tag without name", `query:"tags" example:"a,b"`, Param{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			field := reflect.StructField{Name: "Field", Tag: tt.argTag}
+			if tt.wantPanic {
+				a.Panics(func() { parseTag(field) })
+				return
+			}
+			got := parseTag(field)
+			a.Equal(tt.want.In, got.In)
+			a.Equal(tt.want.Name, got.Name)
+			a.Equal(tt.want.Desc, got.Desc)
+			a.Equal(tt.want.DefaultValue, got.DefaultValue)
+			a.Equal(tt.want.Examples, got.Examples)
 		})
 	}
 }
@@ -6,19 +6,33 @@ import (
 	"github.com/crunk1/xfuego/internal/types"
 )
 
-// parseType determines the Go kind of the field and whether it is required or nullable.
+// parseType determines the Go base type of the field and whether it is a slice, a text type, required, or nullable.
 // Field optionality is determined by the presence of a pointer or not, e.g. `*string` vs `string`.
 // Field nullability is determined by the presence of a Nullable[T] type, which is also a *T under the hood.
 // It is possible that a field is both optional and nullable, e.g. `*Nullable[int]`, so we need to check IsNullable twice.
-func parseType(field reflect.StructField) (goKind reflect.Kind, required bool, nullable bool) {
+// Slice fields, e.g. `[]int` or `*[]int`, report the type of their elements; slices cannot be nullable.
+// Map fields, e.g. `map[string]int`, report the type of their values; maps are optional, and cannot be pointers.
+// Text types are time.Duration and types implementing encoding.TextUnmarshaler; they take precedence over the type's
+// kind, so a text type that is itself a slice (e.g. net.IP) is not treated as a slice param.
+func parseType(field reflect.StructField) (goType reflect.Type, slice bool, isMap bool, text bool, required bool, nullable bool) {
 	required = true
 	nullable = false
 	t := field.Type
+	if t.Kind() == reflect.Map {
+		if t.Key().Kind() != reflect.String {
+			panic("param field map type must be a map[string]T: field=" + field.Name)
+		}
+		isMap, required = true, false
+		t = t.Elem()
+		if t.Kind() == reflect.Pointer || t.Kind() == reflect.Map || (t.Kind() == reflect.Slice && !isText(t)) {
+			panic("param field map value type must be a bool|number|string|encoding.TextUnmarshaler: field=" + field.Name)
+		}
+	}
 	if types.IsNullable(t) {
 		nullable = true
 		t = t.Elem()
 		if t.Kind() == reflect.Pointer {
-			panic("param field Nullable type must be a bool|int|string: field=" + field.Name)
+			panic("param field Nullable type must be a bool|number|string|encoding.TextUnmarshaler: field=" + field.Name)
 		}
 	}
 	if t.Kind() == reflect.Pointer {
@@ -29,9 +43,26 @@ func parseType(field reflect.StructField) (goKind reflect.Kind, required bool, n
 		nullable = true
 		t = t.Elem()
 	}
-	goKind = t.Kind()
-	if goKind != reflect.Bool && goKind != reflect.Int && goKind != reflect.String {
-		panic("param field base type must be a bool|int|string: field=" + field.Name)
+	if t.Kind() == reflect.Map {
+		panic("param field map type must be a map[string]T: field=" + field.Name)
+	}
+	if t.Kind() == reflect.Slice && !isText(t) {
+		if nullable {
+			panic("param field slice type cannot be nullable: field=" + field.Name)
+		}
+		slice = true
+		t = t.Elem()
+	}
+	goType = t
+	if isText(t) {
+		return goType, slice, isMap, true, required, nullable
+	}
+	if _, ok := strconvFns[t.Kind()]; !ok {
+		panic("param field base type must be a bool|number|string|encoding.TextUnmarshaler or a slice of them: field=" + field.Name)
 	}
 	return
 }
+
+func isText(t reflect.Type) bool {
+	return t == durationType || types.IsTextUnmarshaler(t)
+}
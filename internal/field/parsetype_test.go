@@ -1,6 +1,8 @@
 package field
 
 import (
+	"net"
+	"net/netip"
 	"reflect"
 	"testing"
 
@@ -21,17 +23,37 @@ func Test_parseType(t *testing.T) {
 		name         string
 		fieldType    reflect.Type
 		wantGoKind   reflect.Kind
+		wantSlice    bool
+		wantMap      bool
+		wantText     bool
 		wantRequired bool
 		wantNullable bool
 		wantPanic    bool
 	}{
-		{"basic int", intT, reflect.Int, true, false, false},
-		{"optional int - *int", pIntT, reflect.Int, false, false, false},
-		{"nullable int - Nullable[int]", nullableIntT, reflect.Int, true, true, false},
-		{"optional nullable int - *Nullable[int]", pNullableIntT, reflect.Int, false, true, false},
-		{"bad - **int", ppIntT, reflect.Int, false, false, true},
-		{"bad - Nullable[*int]", nullablePIntT, reflect.Int, false, true, true},
-		{"bad non{bool,int,string} - []int", reflect.SliceOf(intT), reflect.Int, false, false, true},
+		{"basic int", intT, reflect.Int, false, false, false, true, false, false},
+		{"optional int - *int", pIntT, reflect.Int, false, false, false, false, false, false},
+		{"nullable int - Nullable[int]", nullableIntT, reflect.Int, false, false, false, true, true, false},
+		{"optional nullable int - *Nullable[int]", pNullableIntT, reflect.Int, false, false, false, false, true, false},
+		{"uint64", reflect.TypeOf(uint64(0)), reflect.Uint64, false, false, false, true, false, false},
+		{"optional float32 - *float32", reflect.TypeOf((*float32)(nil)), reflect.Float32, false, false, false, false, false, false},
+		{"bad - complex128", reflect.TypeOf(complex128(0)), reflect.Complex128, false, false, false, false, false, true},
+		{"bad - **int", ppIntT, reflect.Int, false, false, false, false, false, true},
+		{"bad - Nullable[*int]", nullablePIntT, reflect.Int, false, false, false, false, true, true},
+		{"slice - []int", reflect.SliceOf(intT), reflect.Int, true, false, false, true, false, false},
+		{"optional slice - *[]int", reflect.PointerTo(reflect.SliceOf(intT)), reflect.Int, true, false, false, false, false, false},
+		{"bad - Nullable[[]int]", reflect.TypeOf((*types.Nullable[[]int])(nil)).Elem(), reflect.Int, true, false, false, true, true, true},
+		{"bad - [][]int", reflect.SliceOf(reflect.SliceOf(intT)), reflect.Int, true, false, false, true, false, true},
+		{"text - netip.Addr", reflect.TypeOf(netip.Addr{}), reflect.Struct, false, false, true, true, false, false},
+		{"text slice type - net.IP", reflect.TypeOf(net.IP{}), reflect.Slice, false, false, true, true, false, false},
+		{"optional nullable text - *Nullable[netip.Addr]", reflect.TypeOf((*types.Nullable[netip.Addr])(nil)), reflect.Struct, false, false, true, false, true, false},
+		{"slice of text - []netip.Addr", reflect.TypeOf([]netip.Addr{}), reflect.Struct, true, false, true, true, false, false},
+		{"map - map[string]int", reflect.TypeOf(map[string]int{}), reflect.Int, false, true, false, false, false, false},
+		{"map of text - map[string]netip.Addr", reflect.TypeOf(map[string]netip.Addr{}), reflect.Struct, false, true, true, false, false, false},
+		{"bad - map[int]int", reflect.TypeOf(map[int]int{}), reflect.Int, false, true, false, false, false, true},
+		{"bad - *map[string]int", reflect.TypeOf(&map[string]int{}), reflect.Int, false, true, false, false, false, true},
+		{"bad - map[string]*int", reflect.TypeOf(map[string]*int{}), reflect.Int, false, true, false, false, false, true},
+		{"bad - map[string][]int", reflect.TypeOf(map[string][]int{}), reflect.Int, false, true, false, false, false, true},
+		{"bad non{bool,number,string} - map[string]struct{}", reflect.TypeOf(map[string]struct{}{}), reflect.Struct, false, true, false, false, false, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -41,8 +63,11 @@ func Test_parseType(t *testing.T) {
 				a.Panics(func() { parseType(field) })
 				return
 			}
-			gotGoKind, gotRequired, gotNullable := parseType(field)
-			a.Equalf(tt.wantGoKind, gotGoKind, "parseType(%v)", field)
+			gotGoType, gotSlice, gotMap, gotText, gotRequired, gotNullable := parseType(field)
+			a.Equalf(tt.wantGoKind, gotGoType.Kind(), "parseType(%v)", field)
+			a.Equalf(tt.wantText, gotText, "parseType(%v)", field)
+			a.Equalf(tt.wantSlice, gotSlice, "parseType(%v)", field)
+			a.Equalf(tt.wantMap, gotMap, "parseType(%v)", field)
 			a.Equalf(tt.wantRequired, gotRequired, "parseType(%v)", field)
 			a.Equalf(tt.wantNullable, gotNullable, "parseType(%v)", field)
 		})
@@ -0,0 +1,140 @@
+package field
+
+import (
+	"encoding"
+	"errors"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/crunk1/xfuego/internal/types"
+)
+
+// strconvFns maps each supported base kind to its string conversion function.
+var strconvFns = map[reflect.Kind]func(string) (any, error){
+	reflect.Bool:    strconvBool,
+	reflect.Int:     strconvInt[int],
+	reflect.Int8:    strconvInt[int8],
+	reflect.Int16:   strconvInt[int16],
+	reflect.Int32:   strconvInt[int32],
+	reflect.Int64:   strconvInt[int64],
+	reflect.Uint:    strconvUint[uint],
+	reflect.Uint8:   strconvUint[uint8],
+	reflect.Uint16:  strconvUint[uint16],
+	reflect.Uint32:  strconvUint[uint32],
+	reflect.Uint64:  strconvUint[uint64],
+	reflect.Float32: strconvFloat[float32],
+	reflect.Float64: strconvFloat[float64],
+	reflect.String:  strconvString,
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+var dateType = reflect.TypeFor[types.Date]()
+var durationType = reflect.TypeFor[time.Duration]()
+
+func strconvBool(value string) (any, error) {
+	return convert(ParseBool(value))
+}
+
+// strconvInt parses a signed integer, failing if the value overflows T.
+func strconvInt[T int | int8 | int16 | int32 | int64](value string) (any, error) {
+	return convert(ParseInt[T](value))
+}
+
+// strconvUint parses an unsigned integer, failing if the value overflows T.
+func strconvUint[T uint | uint8 | uint16 | uint32 | uint64](value string) (any, error) {
+	return convert(ParseUint[T](value))
+}
+
+// strconvFloat parses a floating point number, failing if the value overflows T.
+func strconvFloat[T float32 | float64](value string) (any, error) {
+	return convert(ParseFloat[T](value))
+}
+
+// convert returns the result of a typed conversion function as a StrconvFn result.
+func convert[T any](result T, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ParseBool, ParseInt, ParseUint and ParseFloat are the typed StrconvFn of bool and number params, for the populate
+// functions generated by xfuego-gen.
+func ParseBool(value string) (bool, error) {
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.New("value is not a bool: " + strconv.Quote(value))
+	}
+	return result, nil
+}
+
+func ParseInt[T int | int8 | int16 | int32 | int64](value string) (T, error) {
+	result, err := strconv.ParseInt(value, 10, reflect.TypeFor[T]().Bits())
+	if err != nil {
+		return 0, numError(err, reflect.TypeFor[T](), value)
+	}
+	return T(result), nil
+}
+
+func ParseUint[T uint | uint8 | uint16 | uint32 | uint64](value string) (T, error) {
+	result, err := strconv.ParseUint(value, 10, reflect.TypeFor[T]().Bits())
+	if err != nil {
+		return 0, numError(err, reflect.TypeFor[T](), value)
+	}
+	return T(result), nil
+}
+
+func ParseFloat[T float32 | float64](value string) (T, error) {
+	result, err := strconv.ParseFloat(value, reflect.TypeFor[T]().Bits())
+	if err != nil {
+		return 0, numError(err, reflect.TypeFor[T](), value)
+	}
+	return T(result), nil
+}
+
+// numError describes a strconv number parsing error without the strconv function name.
+func numError(err error, t reflect.Type, value string) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return errors.New("value is out of range for " + t.String() + ": " + strconv.Quote(value))
+	}
+	return errors.New("value is not a valid " + t.String() + ": " + strconv.Quote(value))
+}
+
+func strconvString(value string) (any, error) {
+	return value, nil
+}
+
+// strconvDuration parses a time.Duration, e.g. "1h30m".
+func strconvDuration(value string) (any, error) {
+	result, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, errors.New("value is not a valid duration: " + strconv.Quote(value))
+	}
+	return result, nil
+}
+
+// strconvTime returns a conversion function for a time.Time or types.Date with a custom layout.
+func strconvTime(t reflect.Type, layout string) func(string) (any, error) {
+	return func(value string) (any, error) {
+		result, err := time.Parse(layout, value)
+		if err != nil {
+			return nil, errors.New("value does not match the time layout " + strconv.Quote(layout) + ": " + strconv.Quote(value))
+		}
+		if t == dateType {
+			return types.Date{Time: result}, nil
+		}
+		return result, nil
+	}
+}
+
+// strconvText returns a conversion function for a type implementing encoding.TextUnmarshaler.
+func strconvText(t reflect.Type) func(string) (any, error) {
+	return func(value string) (any, error) {
+		v := reflect.New(t)
+		if err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+			return nil, errors.New("value is not a valid " + t.String() + ": " + strconv.Quote(value) + ": " + err.Error())
+		}
+		return v.Elem().Interface(), nil
+	}
+}
@@ -0,0 +1,85 @@
+package field
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/crunk1/xfuego/internal/types"
+)
+
+func Test_strconvFns(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    reflect.Kind
+		value   string
+		want    any
+		wantErr string
+	}{
+		{"bool", reflect.Bool, "true", true, ""},
+		{"not a bool", reflect.Bool, "yes", nil, `value is not a bool: "yes"`},
+		{"int8", reflect.Int8, "-128", int8(-128), ""},
+		{"int8 overflow", reflect.Int8, "128", nil, `value is out of range for int8: "128"`},
+		{"int64", reflect.Int64, "9223372036854775807", int64(9223372036854775807), ""},
+		{"uint16", reflect.Uint16, "65535", uint16(65535), ""},
+		{"uint16 overflow", reflect.Uint16, "65536", nil, `value is out of range for uint16: "65536"`},
+		{"uint negative", reflect.Uint, "-1", nil, `value is not a valid uint: "-1"`},
+		{"float32", reflect.Float32, "1.5", float32(1.5), ""},
+		{"float32 overflow", reflect.Float32, "1e39", nil, `value is out of range for float32: "1e39"`},
+		{"float64", reflect.Float64, "-2.25", -2.25, ""},
+		{"not a number", reflect.Int, "abc", nil, `value is not a valid int: "abc"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			got, err := strconvFns[tt.kind](tt.value)
+			if tt.wantErr != "" {
+				a.EqualError(err, tt.wantErr)
+				return
+			}
+			a.NoError(err)
+			a.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	a := assert.New(t)
+	got, err := ParseInt[int16]("-300")
+	a.NoError(err)
+	a.Equal(int16(-300), got)
+	got, err = ParseInt[int16]("40000")
+	a.EqualError(err, `value is out of range for int16: "40000"`)
+	a.Zero(got)
+}
+
+func Test_strconvText(t *testing.T) {
+	a := assert.New(t)
+	strconvFn := strconvText(reflect.TypeOf(netip.Addr{}))
+	got, err := strconvFn("1.2.3.4")
+	a.NoError(err)
+	a.Equal(netip.MustParseAddr("1.2.3.4"), got)
+	_, err = strconvFn("1.2.3")
+	a.ErrorContains(err, `value is not a valid netip.Addr: "1.2.3"`)
+}
+
+func Test_strconvDuration(t *testing.T) {
+	a := assert.New(t)
+	got, err := strconvDuration("1m30s")
+	a.NoError(err)
+	a.Equal(90*time.Second, got)
+	_, err = strconvDuration("90")
+	a.EqualError(err, `value is not a valid duration: "90"`)
+}
+
+func Test_strconvTime(t *testing.T) {
+	a := assert.New(t)
+	got, err := strconvTime(dateType, "01/02/2006")("01/31/2025")
+	a.NoError(err)
+	a.Equal(types.Date{Time: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)}, got)
+	_, err = strconvTime(timeType, "2006-01-02")("01/31/2025")
+	a.EqualError(err, `value does not match the time layout "2006-01-02": "01/31/2025"`)
+}
@@ -0,0 +1,172 @@
+package field
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseConstraints checks that the param's validation constraints apply to its type, and returns its enum values
+// converted with StrconvFn:
+//   - min, max, multipleOf: numbers
+//   - minLength, maxLength, pattern: strings and text types (applied to the raw value)
+//   - enum: any type
+func parseConstraints(field reflect.StructField, p Param) (enum []any) {
+	number := isNumber(p.GoKind) && !p.Text
+	str := p.GoKind == reflect.String || p.Text
+	if (p.Min != nil || p.Max != nil || p.MultipleOf != nil) && !number {
+		panic("param opts 'min', 'max' and 'multipleOf' are only supported for number params: field=" + field.Name)
+	}
+	if (p.MinLength != nil || p.MaxLength != nil || p.Pattern != nil) && !str {
+		panic("param opts 'minLength', 'maxLength' and 'pattern' are only supported for string params: field=" + field.Name)
+	}
+	for _, enumValue := range p.Enum {
+		value, err := p.StrconvFn(enumValue.(string))
+		if err == nil {
+			err = checkOpenAPIInteger(value)
+		}
+		if err != nil {
+			panic("param opt 'enum' value is invalid: field=" + field.Name + ": " + err.Error())
+		}
+		enum = append(enum, value)
+	}
+	return enum
+}
+
+// Validate checks a single converted value (a slice element for slices) and its raw string against the param's
+// constraints.
+func (p Param) Validate(raw string, value any) error {
+	if p.Min != nil || p.Max != nil || p.MultipleOf != nil {
+		f := numberValue(value)
+		if p.Min != nil && f < *p.Min {
+			return MinError(raw, *p.Min)
+		}
+		if p.Max != nil && f > *p.Max {
+			return MaxError(raw, *p.Max)
+		}
+		if p.MultipleOf != nil && !IsMultipleOf(f, *p.MultipleOf) {
+			return MultipleOfError(raw, *p.MultipleOf)
+		}
+	}
+	if p.MinLength != nil || p.MaxLength != nil {
+		length := uint64(utf8.RuneCountInString(raw))
+		if p.MinLength != nil && length < *p.MinLength {
+			return MinLengthError(raw, *p.MinLength)
+		}
+		if p.MaxLength != nil && length > *p.MaxLength {
+			return MaxLengthError(raw, *p.MaxLength)
+		}
+	}
+	if p.Pattern != nil && !p.Pattern.MatchString(raw) {
+		return PatternError(raw, p.Pattern)
+	}
+	if p.Enum != nil && !p.isEnumValue(value) {
+		return EnumError(raw, p.EnumString())
+	}
+	return nil
+}
+
+// IsMultipleOf reports whether f is a multiple of multipleOf, within floating point precision.
+func IsMultipleOf(f, multipleOf float64) bool {
+	q := f / multipleOf
+	return math.Abs(q-math.Round(q)) <= 1e-9
+}
+
+// MinError, MaxError, MultipleOfError, MinLengthError, MaxLengthError, PatternError and EnumError are the errors of
+// Validate, for the populate functions generated by xfuego-gen.
+func MinError(raw string, min float64) error {
+	return errors.New("value must be greater than or equal to " + formatFloat(min) + ": " + strconv.Quote(raw))
+}
+
+func MaxError(raw string, max float64) error {
+	return errors.New("value must be less than or equal to " + formatFloat(max) + ": " + strconv.Quote(raw))
+}
+
+func MultipleOfError(raw string, multipleOf float64) error {
+	return errors.New("value must be a multiple of " + formatFloat(multipleOf) + ": " + strconv.Quote(raw))
+}
+
+func MinLengthError(raw string, minLength uint64) error {
+	return errors.New("value must be at least " + strconv.FormatUint(minLength, 10) + " characters long: " + strconv.Quote(raw))
+}
+
+func MaxLengthError(raw string, maxLength uint64) error {
+	return errors.New("value must be at most " + strconv.FormatUint(maxLength, 10) + " characters long: " + strconv.Quote(raw))
+}
+
+func PatternError(raw string, pattern *regexp.Regexp) error {
+	return errors.New("value must match the pattern " + strconv.Quote(pattern.String()) + ": " + strconv.Quote(raw))
+}
+
+// EnumError takes the enum values as returned by EnumString.
+func EnumError(raw string, enum string) error {
+	return errors.New("value must be one of " + enum + ": " + strconv.Quote(raw))
+}
+
+func (p Param) isEnumValue(value any) bool {
+	for _, enumValue := range p.Enum {
+		if reflect.DeepEqual(value, enumValue) {
+			return true
+		}
+	}
+	return false
+}
+
+// EnumString returns the enum values as written in the param tag, e.g. "asc|desc".
+func (p Param) EnumString() string {
+	values := make([]string, len(p.Enum))
+	for i, enumValue := range p.Enum {
+		if s, ok := enumValue.(interface{ String() string }); ok {
+			values[i] = s.String()
+		} else {
+			values[i] = reflectString(reflect.ValueOf(enumValue))
+		}
+	}
+	return strings.Join(values, "|")
+}
+
+func reflectString(v reflect.Value) string {
+	switch {
+	case v.Kind() == reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case v.CanInt():
+		return strconv.FormatInt(v.Int(), 10)
+	case v.CanUint():
+		return strconv.FormatUint(v.Uint(), 10)
+	case v.CanFloat():
+		return formatFloat(v.Float())
+	}
+	return v.String()
+}
+
+func numberValue(value any) float64 {
+	v := reflect.ValueOf(value)
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+// checkOpenAPIInteger returns an error if a tag value is an unsigned integer above math.MaxInt, which cannot be
+// documented: fuego requires the OpenAPI integer values of params to be Go ints.
+func checkOpenAPIInteger(value any) error {
+	if v := reflect.ValueOf(value); v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uint64 && v.Uint() > math.MaxInt {
+		return errors.New("value overflows the OpenAPI integer range: " + strconv.FormatUint(v.Uint(), 10))
+	}
+	return nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64 && kind != reflect.Uintptr
+}
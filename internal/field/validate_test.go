@@ -0,0 +1,82 @@
+package field
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_constraints(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldType reflect.Type
+		fieldTag  reflect.StructTag
+		wantEnum  []any
+		wantPanic bool
+	}{
+		{"number constraints", reflect.TypeOf(0), `query:",,default=20,min=1,max=100,multipleOf=5"`, nil, false},
+		{"string constraints", reflect.TypeOf(""), `query:",,minLength=1,maxLength=10,pattern=^[a-z]+$"`, nil, false},
+		{"int enum", reflect.TypeOf([]int8{}), `query:",,enum=1|2|3"`, []any{int8(1), int8(2), int8(3)}, false},
+		{"string enum", reflect.TypeOf(""), `query:",,default=asc,enum=asc|desc"`, []any{"asc", "desc"}, false},
+		{"panic on min for string", reflect.TypeOf(""), `query:",,min=1"`, nil, true},
+		{"panic on pattern for int", reflect.TypeOf(0), `query:",,pattern=^1$"`, nil, true},
+		{"panic on invalid enum value", reflect.TypeOf(0), `query:",,enum=1|two"`, nil, true},
+		{"panic on default below min", reflect.TypeOf(0), `query:",,default=0,min=1"`, nil, true},
+		{"panic on default not in enum", reflect.TypeOf(""), `query:",,default=up,enum=asc|desc"`, nil, true},
+		{"panic on example too long", reflect.TypeOf(""), `query:",,example=long=abcd,maxLength=3"`, nil, true},
+		{"uint64 default below the OpenAPI integer range", reflect.TypeOf(uint64(0)), `query:",,default=9223372036854775807"`, nil, false},
+		{"panic on uint64 default overflowing OpenAPI integer", reflect.TypeOf(uint64(0)), `query:",,default=9223372036854775808"`, nil, true},
+		{"panic on uint example overflowing OpenAPI integer", reflect.TypeOf([]uint{}), `query:",,example=big=1|18446744073709551615"`, nil, true},
+		{"panic on uint64 enum value overflowing OpenAPI integer", reflect.TypeOf(uint64(0)), `query:",,enum=1|18446744073709551615"`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			field := reflect.StructField{Name: "X", Type: tt.fieldType, Tag: tt.fieldTag}
+			if tt.wantPanic {
+				a.Panics(func() { Parse(field) })
+				return
+			}
+			got := Parse(field)
+			a.Equal(tt.wantEnum, got.Enum)
+		})
+	}
+}
+
+func TestParam_Validate(t *testing.T) {
+	intT, stringT := reflect.TypeOf(0), reflect.TypeOf("")
+	tests := []struct {
+		name     string
+		fieldTag reflect.StructTag
+		fieldT   reflect.Type
+		raw      string
+		wantErr  string
+	}{
+		{"min ok", `query:",,min=1"`, intT, "1", ""},
+		{"min", `query:",,min=1"`, intT, "0", `value must be greater than or equal to 1: "0"`},
+		{"max", `query:",,max=1.5"`, reflect.TypeOf(0.0), "2", `value must be less than or equal to 1.5: "2"`},
+		{"multipleOf ok", `query:",,multipleOf=0.1"`, reflect.TypeOf(0.0), "0.3", ""},
+		{"multipleOf", `query:",,multipleOf=5"`, intT, "12", `value must be a multiple of 5: "12"`},
+		{"minLength", `query:",,minLength=2"`, stringT, "é", `value must be at least 2 characters long: "é"`},
+		{"maxLength ok", `query:",,maxLength=2"`, stringT, "éé", ""},
+		{"maxLength", `query:",,maxLength=2"`, stringT, "abc", `value must be at most 2 characters long: "abc"`},
+		{"pattern", `query:",,pattern=^[a-z]+$"`, stringT, "ab1", `value must match the pattern "^[a-z]+$": "ab1"`},
+		{"enum ok", `query:",,enum=1|2"`, reflect.TypeOf(uint16(0)), "2", ""},
+		{"enum", `query:",,enum=asc|desc"`, stringT, "up", `value must be one of asc|desc: "up"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			p := Parse(reflect.StructField{Name: "X", Type: tt.fieldT, Tag: tt.fieldTag})
+			value, err := p.StrconvFn(tt.raw)
+			a.NoError(err)
+			err = p.Validate(tt.raw, value)
+			if tt.wantErr == "" {
+				a.NoError(err)
+			} else {
+				a.EqualError(err, tt.wantErr)
+			}
+		})
+	}
+}
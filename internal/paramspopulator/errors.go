@@ -0,0 +1,41 @@
+package paramspopulator
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/crunk1/xfuego/internal/field"
+)
+
+// ErrMissing is the reason of the ParamError of a missing required param.
+var ErrMissing = errors.New("required value is missing")
+
+// ParamError describes a request param that could not be populated or is invalid.
+type ParamError struct {
+	Name   string
+	In     field.In
+	Reason string
+}
+
+func newParamError(p field.Param, err error) *ParamError {
+	return &ParamError{Name: p.Name, In: p.In, Reason: err.Error()}
+}
+
+func (e ParamError) Error() string {
+	if e.In == field.InNone {
+		return "param " + strconv.Quote(e.Name) + ": " + e.Reason
+	}
+	return e.In.String() + " param " + strconv.Quote(e.Name) + ": " + e.Reason
+}
+
+// ParamErrors lists all the params of a request that could not be populated or are invalid.
+type ParamErrors []ParamError
+
+func (e ParamErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
@@ -0,0 +1,34 @@
+package paramspopulator
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/crunk1/xfuego/internal/field"
+	"github.com/crunk1/xfuego/internal/types"
+)
+
+// GenerateParseForm returns a function that parses the application/x-www-form-urlencoded body of a request, which the
+// form params of ReqParamsT are read from, or nil if ReqParamsT has no form params. The function fails if the body
+// cannot be parsed, e.g. if it is malformed or too large; a body of another content type has no form fields.
+func GenerateParseForm[ReqParamsT any]() func(r *http.Request) error {
+	if types.IsNoneType[ReqParamsT]() {
+		return nil
+	}
+	for _, p := range field.ParseStruct(reflect.TypeOf((*ReqParamsT)(nil)).Elem()) {
+		if p.In == field.InForm {
+			return (*http.Request).ParseForm
+		}
+	}
+	return nil
+}
+
+// formValues returns the form fields of the request body. The body is parsed on first use, its errors are reported
+// by the function returned by GenerateParseForm.
+func formValues(r *http.Request) url.Values {
+	if r.PostForm == nil {
+		_ = r.ParseForm()
+	}
+	return r.PostForm
+}
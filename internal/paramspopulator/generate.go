@@ -1,12 +1,17 @@
 // Package paramspopulator.Generate generates a function that populates a struct with parameters from the request
 // context.
 //
-// Params population logic assumes that validation has already been done before being called.
+// Params population reports every param value that cannot be converted to its field's type as a ParamError.
 package paramspopulator
 
 import (
+	"errors"
+	"maps"
 	"net/http"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 	"unsafe"
 
@@ -14,93 +19,272 @@ import (
 	"github.com/crunk1/xfuego/internal/types"
 )
 
-func Generate[ReqParamsT any]() func(fuegoContextGetters, *ReqParamsT) {
+// Generate returns a function that populates a ReqParamsT struct from the request. The function returns ParamErrors,
+// listing every param that could not be populated, or nil.
+func Generate[ReqParamsT any]() func(ContextGetters, *ReqParamsT) error {
 	// No params -> no-op
 	if types.IsNoneType[ReqParamsT]() {
-		return func(fuegoContextGetters, *ReqParamsT) {}
+		return func(ContextGetters, *ReqParamsT) error { return nil }
 	}
 
 	t := reflect.TypeOf((*ReqParamsT)(nil)).Elem()
 
-	var populators []func(c fuegoContextGetters, params *ReqParamsT)
-	for i := 0; i < t.NumField(); i++ {
-		populator := fieldPopulator[ReqParamsT](t.Field(i))
-		if populator == nil {
-			continue
-		}
-		populators = append(populators, populator)
+	// Generated by xfuego-gen -> no reflection
+	if populate, _ := generatedPopulator[ReqParamsT](t); populate != nil {
+		return populate
+	}
+
+	var populators []func(c ContextGetters, params *ReqParamsT) ParamErrors
+	for _, p := range field.ParseStruct(t) {
+		populators = append(populators, fieldPopulator[ReqParamsT](p))
 	}
 
-	return func(c fuegoContextGetters, params *ReqParamsT) {
+	return func(c ContextGetters, params *ReqParamsT) error {
+		var errs ParamErrors
 		for _, populator := range populators {
-			populator(c, params)
+			errs = append(errs, populator(c, params)...)
+		}
+		if len(errs) > 0 {
+			return errs
 		}
+		return nil
 	}
 }
 
-// fieldPopulator returns a function that populates a field in a Params struct (or nil if the field is not a parameter).
-func fieldPopulator[ReqParamsT any](f reflect.StructField) func(c fuegoContextGetters, params *ReqParamsT) {
-	in, goKind, _, _, strconvFn, name, _, defaultValue, _ := field.Parse(f)
-	if in == field.InNone {
+// fieldPopulator returns a function that populates a param field in a Params struct.
+func fieldPopulator[ReqParamsT any](p field.Param) func(c ContextGetters, params *ReqParamsT) ParamErrors {
+	if p.Map {
+		return mapFieldPopulator[ReqParamsT](p)
+	}
+	populate := valueFieldPopulator[ReqParamsT](p)
+	if p.Slice {
+		populate = sliceFieldPopulator[ReqParamsT](p)
+	}
+	return func(c ContextGetters, params *ReqParamsT) ParamErrors {
+		if err := populate(c, params); err != nil {
+			return ParamErrors{*err}
+		}
 		return nil
 	}
-	getFieldValueFn := getFns[in]
-	setFieldValueFn := setFns[goKind]
+}
+
+// valueFieldPopulator returns a function that populates a single-valued param field in a Params struct.
+func valueFieldPopulator[ReqParamsT any](p field.Param) func(c ContextGetters, params *ReqParamsT) *ParamError {
+	f := p.Field
+	getFieldValueFn := aliasedGetFn(getFn(p), p.Aliases, func(a, b string) bool { return a == b })
+	setFieldValueFn := setFns[p.GoKind]
+	if p.Text {
+		setFieldValueFn = setReflectFn(f.Type)
+	}
 	indirectionLevel := getFieldIndirectionLevel(f)
+	required := p.Required || p.In == field.InPath // path params are always required
 
 	fieldOffset := f.Offset
 
-	return func(c fuegoContextGetters, params *ReqParamsT) {
-		fieldPtr := getFieldPtr(params, fieldOffset)
-		valueStr, ok := getFieldValueFn(c, name)
-		// If !ok, the field must be optional.
+	return func(c ContextGetters, params *ReqParamsT) *ParamError {
+		fieldPtr := getFieldPtr(params, p.Embeddings, fieldOffset)
+		valueStr, ok, err := getFieldValueFn(c, p.Name)
+		if err != nil {
+			return newParamError(p, err)
+		}
 		if !ok {
-			if defaultValue != nil {
-				setFieldValueFn(fieldPtr, indirectionLevel, defaultValue)
+			if required {
+				return newParamError(p, ErrMissing)
+			}
+			if p.DefaultValue != nil {
+				setFieldValueFn(fieldPtr, indirectionLevel, p.DefaultValue)
 			}
-			return
+			return nil
 		}
 		// "null" handling
-		if valueStr == "null" {
+		if p.Nullable && valueStr == "null" {
 			setFieldValueNull(fieldPtr, indirectionLevel)
-			return
+			return nil
+		}
+		// Convert the value to the correct type, validate and set it.
+		value, err := p.StrconvFn(valueStr)
+		if err == nil {
+			err = p.Validate(valueStr, value)
+		}
+		if err != nil {
+			return newParamError(p, err)
+		}
+		setFieldValueFn(fieldPtr, indirectionLevel, value)
+		return nil
+	}
+}
+
+// sliceFieldPopulator returns a function that populates a []T or *[]T field from all the values of a param.
+// Values are split according to the param's serialization style, e.g. `?id=1|2|3` for style=pipeDelimited.
+func sliceFieldPopulator[ReqParamsT any](p field.Param) func(c ContextGetters, params *ReqParamsT) *ParamError {
+	f := p.Field
+	getFieldValuesFn := aliasedGetFn(getSliceFns[p.In], p.Aliases, slices.Equal[[]string])
+	delimiter := p.Delimiter()
+	setFieldValueFn := setSliceFns[p.GoKind]
+	if p.Text {
+		setFieldValueFn = setReflectFn(f.Type)
+	}
+	indirectionLevel := getFieldIndirectionLevel(f)
+	required := p.Required || p.In == field.InPath // path params are always required
+
+	fieldOffset := f.Offset
+
+	return func(c ContextGetters, params *ReqParamsT) *ParamError {
+		fieldPtr := getFieldPtr(params, p.Embeddings, fieldOffset)
+		valueStrs, ok, err := getFieldValuesFn(c, p.Name)
+		if err != nil {
+			return newParamError(p, err)
+		}
+		if !ok {
+			if required {
+				return newParamError(p, ErrMissing)
+			}
+			if p.DefaultValue != nil {
+				setFieldValueFn(fieldPtr, indirectionLevel, p.DefaultValue)
+			}
+			return nil
 		}
-		// Convert the value to the correct type and set it.
-		setFieldValueFn(fieldPtr, indirectionLevel, strconvFn(valueStr))
+		if delimiter != "" {
+			valueStrs = splitLists(valueStrs, delimiter)
+		}
+		values := make([]any, len(valueStrs))
+		for i, valueStr := range valueStrs {
+			value, err := p.StrconvFn(valueStr)
+			if err == nil {
+				err = p.Validate(valueStr, value)
+			}
+			if err != nil {
+				return newParamError(p, err)
+			}
+			values[i] = value
+		}
+		setFieldValueFn(fieldPtr, indirectionLevel, values)
+		return nil
 	}
 }
 
-// fuegoContextGetters is a subset of the fuego.ContextWithBody[T] interface that is used to get values from the request.
-type fuegoContextGetters interface {
+// mapFieldPopulator returns a function that populates a map[string]T field from all the params prefixed with the param
+// name, e.g. `?label.env=prod&label.team=core` for the name "label.". The field is left nil if there are none. Every
+// invalid key is reported, sorted by key, and the field is left unset if there is any.
+func mapFieldPopulator[ReqParamsT any](p field.Param) func(c ContextGetters, params *ReqParamsT) ParamErrors {
+	getFieldValuesFn := getMapFns[p.In]
+	mapType := p.Field.Type
+	fieldOffset := p.Field.Offset
+
+	return func(c ContextGetters, params *ReqParamsT) ParamErrors {
+		valueStrs := getFieldValuesFn(c.Request(), p.Name)
+		if len(valueStrs) == 0 {
+			return nil
+		}
+		var errs ParamErrors
+		m := reflect.MakeMapWithSize(mapType, len(valueStrs))
+		for _, key := range slices.Sorted(maps.Keys(valueStrs)) {
+			valueStr := valueStrs[key]
+			value, err := p.StrconvFn(valueStr)
+			if err == nil {
+				err = p.Validate(valueStr, value)
+			}
+			if err != nil {
+				paramErr := newParamError(p, err)
+				paramErr.Name += key
+				if p.In == field.InHeader {
+					paramErr.Name = http.CanonicalHeaderKey(paramErr.Name)
+				}
+				errs = append(errs, *paramErr)
+				continue
+			}
+			m.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(value).Convert(mapType.Elem()))
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		fieldPtr := getFieldPtr(params, p.Embeddings, fieldOffset)
+		reflect.NewAt(mapType, fieldPtr).Elem().Set(m)
+		return nil
+	}
+}
+
+// ContextGetters is the part of fuego.ContextWithBody used to populate params, whatever the request body type.
+type ContextGetters interface {
+	Request() *http.Request
+
 	PathParam(name string) string
 	HasQueryParam(name string) bool
 	QueryParam(name string) string
+	QueryParamArr(name string) []string
 	HasHeader(name string) bool
 	Header(name string) string
 	HasCookie(name string) bool
 	Cookie(name string) (*http.Cookie, error)
 }
 
-var getFns = map[field.In]func(fuegoContextGetters, string) (string, bool){
+// aliasedGetFn returns a get function of the value of a param sent under its name or any of its aliases, which fails if
+// several of them are sent with different values.
+func aliasedGetFn[V any](getFn func(ContextGetters, string) (V, bool), aliases []string, equal func(a, b V) bool) func(ContextGetters, string) (V, bool, error) {
+	return func(c ContextGetters, name string) (V, bool, error) {
+		value, ok := getFn(c, name)
+		sentName := name
+		for _, alias := range aliases {
+			aliasValue, aliasOk := getFn(c, alias)
+			if !aliasOk {
+				continue
+			}
+			if !ok {
+				value, ok, sentName = aliasValue, true, alias
+			} else if !equal(value, aliasValue) {
+				return value, false, errors.New("conflicting values sent as " + strconv.Quote(sentName) + " and " + strconv.Quote(alias))
+			}
+		}
+		return value, ok, nil
+	}
+}
+
+var getFns = map[field.In]func(ContextGetters, string) (string, bool){
 	field.InQuery:  getQueryValue,
 	field.InPath:   getPathValue,
 	field.InHeader: getHeaderValue,
 	field.InCookie: getCookieValue,
+	field.InForm:   getFormValue,
+}
+
+// getFn returns the get function of a scalar param. The params of nested struct params are read from the URL query:
+// fuego warns about reading query params it does not know of, e.g. `filter[status]` when only the `filter` object param
+// is declared.
+func getFn(p field.Param) func(ContextGetters, string) (string, bool) {
+	if p.Object != nil {
+		return getObjectValue
+	}
+	return getFns[p.In]
 }
 
-func getPathValue(c fuegoContextGetters, name string) (string, bool) {
-	return c.PathParam(name), true
+func getObjectValue(c ContextGetters, name string) (string, bool) {
+	query := c.Request().URL.Query()
+	return query.Get(name), query.Has(name)
 }
 
-func getQueryValue(c fuegoContextGetters, name string) (string, bool) {
+// getPathValue reports empty path values as missing, e.g. a `/foo/{id}` route matching `/foo/`.
+func getPathValue(c ContextGetters, name string) (string, bool) {
+	value := c.PathParam(name)
+	return value, value != ""
+}
+
+func getQueryValue(c ContextGetters, name string) (string, bool) {
 	return c.QueryParam(name), c.HasQueryParam(name)
 }
 
-func getHeaderValue(c fuegoContextGetters, name string) (string, bool) {
+func getFormValue(c ContextGetters, name string) (string, bool) {
+	values, ok := getFormValues(c, name)
+	if !ok {
+		return "", false
+	}
+	return values[0], true
+}
+
+func getHeaderValue(c ContextGetters, name string) (string, bool) {
 	return c.Header(name), c.HasHeader(name)
 }
 
-func getCookieValue(c fuegoContextGetters, name string) (string, bool) {
+func getCookieValue(c ContextGetters, name string) (string, bool) {
 	if !c.HasCookie(name) {
 		return "", false
 	}
@@ -114,10 +298,109 @@ func getCookieValue(c fuegoContextGetters, name string) (string, bool) {
 	return cookie.Value, true
 }
 
+// getSliceFns get all the raw values of a param: the repeated query and form values (`?tag=a&tag=b`), the repeated
+// header lines, or the single cookie value. Delimited values (`?tag=a,b`, `X-Tags: a, b`) are split by the slice
+// populator.
+var getSliceFns = map[field.In]func(ContextGetters, string) ([]string, bool){
+	field.InQuery:  getQueryValues,
+	field.InHeader: getHeaderValues,
+	field.InCookie: getCookieValues,
+	field.InForm:   getFormValues,
+}
+
+func getQueryValues(c ContextGetters, name string) ([]string, bool) {
+	return c.QueryParamArr(name), c.HasQueryParam(name)
+}
+
+func getFormValues(c ContextGetters, name string) ([]string, bool) {
+	values, ok := formValues(c.Request())[name]
+	return values, ok
+}
+
+// getHeaderValues reports a header as present as getHeaderValue does, i.e. if its first line is not empty.
+func getHeaderValues(c ContextGetters, name string) ([]string, bool) {
+	values := c.Request().Header.Values(name)
+	if len(values) == 0 || values[0] == "" {
+		return nil, false
+	}
+	return values, true
+}
+
+func getCookieValues(c ContextGetters, name string) ([]string, bool) {
+	value, ok := getCookieValue(c, name)
+	if !ok {
+		return nil, false
+	}
+	return []string{value}, true
+}
+
+// getMapFns get the values of all the params prefixed with a name, keyed by their unprefixed names. Header names are
+// matched case-insensitively, and the keys are the rest of their canonical names, e.g. "Foo" for `X-Meta-Foo`.
+// Only the first value of repeated params is used.
+var getMapFns = map[field.In]func(r *http.Request, prefix string) map[string]string{
+	field.InQuery:  getQueryMap,
+	field.InHeader: getHeaderMap,
+	field.InCookie: getCookieMap,
+}
+
+func getQueryMap(r *http.Request, prefix string) map[string]string {
+	values := map[string]string{}
+	for name, v := range r.URL.Query() {
+		if key, ok := strings.CutPrefix(name, prefix); ok && key != "" {
+			values[key] = v[0]
+		}
+	}
+	return values
+}
+
+func getHeaderMap(r *http.Request, prefix string) map[string]string {
+	values := map[string]string{}
+	for name, v := range r.Header {
+		if len(name) > len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+			values[name[len(prefix):]] = v[0]
+		}
+	}
+	return values
+}
+
+func getCookieMap(r *http.Request, prefix string) map[string]string {
+	values := map[string]string{}
+	for _, cookie := range r.Cookies() {
+		if key, ok := strings.CutPrefix(cookie.Name, prefix); ok && key != "" {
+			if _, ok := values[key]; !ok {
+				values[key] = cookie.Value
+			}
+		}
+	}
+	return values
+}
+
+// splitLists splits delimited values, trimming optional whitespace around the elements.
+func splitLists(values []string, delimiter string) []string {
+	var result []string
+	for _, value := range values {
+		for _, v := range strings.Split(value, delimiter) {
+			result = append(result, strings.TrimSpace(v))
+		}
+	}
+	return result
+}
+
 var setFns = map[reflect.Kind]func(fieldPtr unsafe.Pointer, indirectionLevel int, value any){
-	reflect.Bool:   setFn[bool],
-	reflect.Int:    setFn[int],
-	reflect.String: setFn[string],
+	reflect.Bool:    setFn[bool],
+	reflect.Int:     setFn[int],
+	reflect.Int8:    setFn[int8],
+	reflect.Int16:   setFn[int16],
+	reflect.Int32:   setFn[int32],
+	reflect.Int64:   setFn[int64],
+	reflect.Uint:    setFn[uint],
+	reflect.Uint8:   setFn[uint8],
+	reflect.Uint16:  setFn[uint16],
+	reflect.Uint32:  setFn[uint32],
+	reflect.Uint64:  setFn[uint64],
+	reflect.Float32: setFn[float32],
+	reflect.Float64: setFn[float64],
+	reflect.String:  setFn[string],
 }
 
 func setFn[T any](fieldPtr unsafe.Pointer, indirectionLevel int, value any) {
@@ -132,6 +415,61 @@ func setFn[T any](fieldPtr unsafe.Pointer, indirectionLevel int, value any) {
 	}
 }
 
+var setSliceFns = map[reflect.Kind]func(fieldPtr unsafe.Pointer, indirectionLevel int, values any){
+	reflect.Bool:    setSliceFn[bool],
+	reflect.Int:     setSliceFn[int],
+	reflect.Int8:    setSliceFn[int8],
+	reflect.Int16:   setSliceFn[int16],
+	reflect.Int32:   setSliceFn[int32],
+	reflect.Int64:   setSliceFn[int64],
+	reflect.Uint:    setSliceFn[uint],
+	reflect.Uint8:   setSliceFn[uint8],
+	reflect.Uint16:  setSliceFn[uint16],
+	reflect.Uint32:  setSliceFn[uint32],
+	reflect.Uint64:  setSliceFn[uint64],
+	reflect.Float32: setSliceFn[float32],
+	reflect.Float64: setSliceFn[float64],
+	reflect.String:  setSliceFn[string],
+}
+
+// setSliceFn converts a []any of T values to a []T and sets it.
+func setSliceFn[T any](fieldPtr unsafe.Pointer, indirectionLevel int, values any) {
+	anyValues := values.([]any)
+	v := make([]T, len(anyValues))
+	for i, value := range anyValues {
+		v[i] = value.(T)
+	}
+	setFn[[]T](fieldPtr, indirectionLevel, v)
+}
+
+// setReflectFn returns a set function for field types that can't be set by kind, i.e. encoding.TextUnmarshaler types.
+// The value is either a value of the field's base type or, for slices, a []any of them.
+func setReflectFn(fieldType reflect.Type) func(fieldPtr unsafe.Pointer, indirectionLevel int, value any) {
+	return func(fieldPtr unsafe.Pointer, _ int, value any) {
+		reflect.NewAt(fieldType, fieldPtr).Elem().Set(reflectValue(fieldType, value))
+	}
+}
+
+// reflectValue builds a value of type t from a base value, allocating the pointers (i.e. *T, Nullable[T]) and slices
+// in between.
+func reflectValue(t reflect.Type, value any) reflect.Value {
+	v := reflect.ValueOf(value)
+	if v.Type() == t {
+		return v
+	}
+	if t.Kind() == reflect.Pointer {
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(reflectValue(t.Elem(), value))
+		return ptr.Convert(t)
+	}
+	values := value.([]any)
+	slice := reflect.MakeSlice(t, len(values), len(values))
+	for i, value := range values {
+		slice.Index(i).Set(reflectValue(t.Elem(), value))
+	}
+	return slice
+}
+
 // setFieldValueNull is called on `"null"` string values.
 // This means that the field is either a Nullable[T] or a *Nullable[T] - 1 or 2 levels of indirection.
 func setFieldValueNull(fieldPtr unsafe.Pointer, indirectionLevel int) {
@@ -161,6 +499,18 @@ func getFieldIndirectionLevel(f reflect.StructField) int {
 	return indirectionLevel
 }
 
-func getFieldPtr[T any](params *T, fieldOffset uintptr) unsafe.Pointer {
-	return unsafe.Pointer(uintptr(unsafe.Pointer(params)) + fieldOffset)
+// getFieldPtr returns a pointer to a field of params, reached through embeddings. Nil pointer embeddings are allocated.
+func getFieldPtr[T any](params *T, embeddings []field.Embedding, fieldOffset uintptr) unsafe.Pointer {
+	structPtr := unsafe.Pointer(params)
+	for _, embedding := range embeddings {
+		structPtr = unsafe.Add(structPtr, embedding.Offset)
+		if embedding.Ptr {
+			embeddedPtr := (*unsafe.Pointer)(structPtr)
+			if *embeddedPtr == nil {
+				*embeddedPtr = reflect.New(embedding.Type).UnsafePointer()
+			}
+			structPtr = *embeddedPtr
+		}
+	}
+	return unsafe.Add(structPtr, fieldOffset)
 }
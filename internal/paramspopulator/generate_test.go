@@ -1,8 +1,13 @@
 package paramspopulator
 
 import (
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 	"unsafe"
@@ -10,6 +15,7 @@ import (
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/crunk1/xfuego/internal/field"
 	"github.com/crunk1/xfuego/internal/types"
 )
 
@@ -41,12 +47,417 @@ func TestGenerate(t *testing.T) {
 			populate := Generate[Params]()
 			getters := &mockGetters{query: tt.queryParams}
 			params := &Params{}
-			populate(getters, params)
+			a.NoError(populate(getters, params))
 			a.Equal(tt.want, *params)
 		})
 	}
 }
 
+func TestGenerate_numericKinds(t *testing.T) {
+	type Params struct {
+		Int8    int8                    `query:""`
+		Uint16  *uint16                 `query:""`
+		Int64   int64                   `query:",,default=-9000000000"`
+		Uint64  uint64                  `header:""`
+		Float32 float32                 `query:""`
+		Float64 types.Nullable[float64] `cookie:""`
+	}
+	a := assert.New(t)
+	populate := Generate[Params]()
+	getters := &mockGetters{
+		query:   map[string]string{"Int8": "-8", "Uint16": "16", "Float32": "3.5"},
+		headers: map[string]string{"Uint64": "18446744073709551615"},
+		cookies: map[string]*http.Cookie{"Float64": {Name: "Float64", Value: "6.25"}},
+	}
+	params := &Params{}
+	a.NoError(populate(getters, params))
+	a.Equal(Params{
+		Int8:    -8,
+		Uint16:  lo.ToPtr(uint16(16)),
+		Int64:   -9000000000,
+		Uint64:  18446744073709551615,
+		Float32: 3.5,
+		Float64: types.Nullable[float64](lo.ToPtr(6.25)),
+	}, *params)
+}
+
+func TestGenerate_slices(t *testing.T) {
+	type Params struct {
+		Tags      []string   `query:"tag"`
+		IDs       *[]int     `query:"id"`
+		Defaulted []int      `query:",,default=1|2"`
+		Header    []uint8    `header:""`
+		Cookie    *[]float64 `cookie:""`
+		Unset     *[]string  `header:""`
+	}
+	a := assert.New(t)
+	populate := Generate[Params]()
+	getters := &mockGetters{
+		queryArr: map[string][]string{"tag": {"a", "b"}, "id": {"3"}},
+		headers:  map[string]string{"Header": "1, 2,3"},
+		cookies:  map[string]*http.Cookie{"Cookie": {Name: "Cookie", Value: "1.5,2.5"}},
+	}
+	params := &Params{}
+	a.NoError(populate(getters, params))
+	a.Equal(Params{
+		Tags:      []string{"a", "b"},
+		IDs:       &[]int{3},
+		Defaulted: []int{1, 2},
+		Header:    []uint8{1, 2, 3},
+		Cookie:    &[]float64{1.5, 2.5},
+	}, *params)
+}
+
+func TestGenerate_repeatedHeaders(t *testing.T) {
+	type Params struct {
+		Tags []string `header:"X-Tags"`
+		IDs  *[]int   `header:"X-IDs"`
+	}
+	a := assert.New(t)
+	populate := Generate[Params]()
+	params := &Params{}
+	a.NoError(populate(&mockGetters{headerLines: map[string][]string{"X-Tags": {"a", "b, c"}}}, params))
+	a.Equal(Params{Tags: []string{"a", "b", "c"}}, *params)
+
+	err := populate(&mockGetters{headerLines: map[string][]string{"X-Tags": {"a"}, "X-Ids": {"1", "x"}}}, &Params{})
+	a.Equal(ParamErrors{{Name: "X-IDs", In: field.InHeader, Reason: `value is not a valid int: "x"`}}, err)
+}
+
+func TestGenerate_sliceStyles(t *testing.T) {
+	type Params struct {
+		Form    []int    `query:"form,,explode=false"`
+		Space   []int    `query:"space,,style=spaceDelimited,explode=false"`
+		Pipe    []string `query:"pipe,,style=pipeDelimited,explode=false"`
+		Default []string `query:"default,,style=pipeDelimited"`
+	}
+	a := assert.New(t)
+	populate := Generate[Params]()
+	getters := &mockGetters{
+		queryArr: map[string][]string{"form": {"1,2", "3"}, "space": {"4 5"}, "pipe": {"a|b,c"}, "default": {"d|e", "f"}},
+	}
+	params := &Params{}
+	a.NoError(populate(getters, params))
+	a.Equal(Params{
+		Form:    []int{1, 2, 3},
+		Space:   []int{4, 5},
+		Pipe:    []string{"a", "b,c"},
+		Default: []string{"d", "e", "f"},
+	}, *params)
+}
+
+func TestGenerate_textUnmarshalers(t *testing.T) {
+	type Params struct {
+		Addr        netip.Addr                  `query:""`
+		OptAddr     *netip.Addr                 `query:""`
+		NullAddr    types.Nullable[netip.Addr]  `query:""`
+		OptNullAddr *types.Nullable[netip.Addr] `header:""`
+		DefaultAddr netip.Addr                  `query:",,default=10.0.0.1"`
+		Addrs       []netip.Addr                `query:""`
+		OptAddrs    *[]netip.Addr               `cookie:""`
+		IP          net.IP                      `query:""`
+	}
+	a := assert.New(t)
+	populate := Generate[Params]()
+	getters := &mockGetters{
+		query:    map[string]string{"Addr": "127.0.0.1", "OptAddr": "::1", "NullAddr": "null", "IP": "192.168.0.1"},
+		queryArr: map[string][]string{"Addrs": {"1.1.1.1", "8.8.8.8"}},
+		headers:  map[string]string{"OptNullAddr": "1.2.3.4"},
+		cookies:  map[string]*http.Cookie{"OptAddrs": {Name: "OptAddrs", Value: "9.9.9.9"}},
+	}
+	params := &Params{}
+	a.NoError(populate(getters, params))
+	a.Equal(Params{
+		Addr:        netip.MustParseAddr("127.0.0.1"),
+		OptAddr:     lo.ToPtr(netip.MustParseAddr("::1")),
+		OptNullAddr: lo.ToPtr(types.Nullable[netip.Addr](lo.ToPtr(netip.MustParseAddr("1.2.3.4")))),
+		DefaultAddr: netip.MustParseAddr("10.0.0.1"),
+		Addrs:       []netip.Addr{netip.MustParseAddr("1.1.1.1"), netip.MustParseAddr("8.8.8.8")},
+		OptAddrs:    &[]netip.Addr{netip.MustParseAddr("9.9.9.9")},
+		IP:          net.ParseIP("192.168.0.1"),
+	}, *params)
+}
+
+func TestGenerate_time(t *testing.T) {
+	type Params struct {
+		Since   time.Time       `query:"since"`
+		Until   *time.Time      `query:"until,,layout=2006-01-02 15:04"`
+		Day     types.Date      `query:"day"`
+		Timeout time.Duration   `header:"X-Timeout,,default=30s"`
+		Steps   []time.Duration `query:"step,,explode=false"`
+	}
+	a := assert.New(t)
+	populate := Generate[Params]()
+	getters := &mockGetters{
+		query:    map[string]string{"since": "2025-01-01T00:00:00Z", "until": "2025-02-01 12:30", "day": "2025-03-01"},
+		queryArr: map[string][]string{"step": {"1s,2m"}},
+	}
+	params := &Params{}
+	a.NoError(populate(getters, params))
+	a.Equal(Params{
+		Since:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Until:   lo.ToPtr(time.Date(2025, 2, 1, 12, 30, 0, 0, time.UTC)),
+		Day:     types.Date{Time: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		Timeout: 30 * time.Second,
+		Steps:   []time.Duration{time.Second, 2 * time.Minute},
+	}, *params)
+}
+
+func TestGenerate_errors(t *testing.T) {
+	type Params struct {
+		Int      int                    `query:"int"`
+		Nullable types.Nullable[string] `query:"nullable"`
+		NotNull  string                 `query:"notNull"`
+		Header   *uint8                 `header:"X-Count"`
+		Cookie   []int                  `cookie:"ids"`
+		Valid    int                    `query:"valid"`
+	}
+	a := assert.New(t)
+	populate := Generate[Params]()
+	getters := &mockGetters{
+		query:   map[string]string{"int": "abc", "nullable": "null", "notNull": "null", "valid": "1"},
+		headers: map[string]string{"X-Count": "256"},
+		cookies: map[string]*http.Cookie{"ids": {Name: "ids", Value: "1,x"}},
+	}
+	params := &Params{}
+	err := populate(getters, params)
+	a.Equal(ParamErrors{
+		{Name: "int", In: field.InQuery, Reason: `value is not a valid int: "abc"`},
+		{Name: "X-Count", In: field.InHeader, Reason: `value is out of range for uint8: "256"`},
+		{Name: "ids", In: field.InCookie, Reason: `value is not a valid int: "x"`},
+	}, err)
+	a.EqualError(err, `query param "int": value is not a valid int: "abc"; header param "X-Count": value is out of range for uint8: "256"; cookie param "ids": value is not a valid int: "x"`)
+	a.Equal(Params{NotNull: "null", Valid: 1}, *params) // "null" is only special for nullable params
+}
+
+func TestGenerate_aliases(t *testing.T) {
+	type Params struct {
+		Limit  int      `query:"limit,,alias=page_size|pageSize"`
+		Offset *int     `query:"offset,,alias=skip"`
+		Tags   []string `query:"tag,,alias=tags"`
+		Sort   string   `header:"X-Sort,,alias=X-Order"`
+	}
+	a := assert.New(t)
+	populate := Generate[Params]()
+
+	// Sent under an alias, or under several names with the same value
+	params := &Params{}
+	a.NoError(populate(&mockGetters{
+		query:    map[string]string{"page_size": "10", "pageSize": "10"},
+		queryArr: map[string][]string{"tags": {"a", "b"}},
+		headers:  map[string]string{"X-Sort": "asc", "X-Order": "asc"},
+	}, params))
+	a.Equal(Params{Limit: 10, Tags: []string{"a", "b"}, Sort: "asc"}, *params)
+
+	// Sent under several names with different values
+	err := populate(&mockGetters{
+		query:    map[string]string{"limit": "10", "pageSize": "20", "skip": "1"},
+		queryArr: map[string][]string{"tag": {"a"}, "tags": {"a", "b"}},
+		headers:  map[string]string{"X-Order": "asc"},
+	}, &Params{})
+	a.Equal(ParamErrors{
+		{Name: "limit", In: field.InQuery, Reason: `conflicting values sent as "limit" and "pageSize"`},
+		{Name: "tag", In: field.InQuery, Reason: `conflicting values sent as "tag" and "tags"`},
+	}, err)
+}
+
+func TestGenerate_form(t *testing.T) {
+	type Params struct {
+		User     string                 `form:"user,,minLength=2,alias=login"`
+		Remember bool                   `form:"remember,,default=true"`
+		Scopes   []string               `form:"scope,,enum=read|write"`
+		Note     types.Nullable[string] `form:"note"`
+		IDs      *[]int                 `form:"ids,,style=pipeDelimited,explode=false"`
+		Limit    int                    `query:"limit,,default=20"`
+	}
+	a := assert.New(t)
+	populate := Generate[Params]()
+
+	params := &Params{}
+	a.NoError(populate(&mockGetters{
+		query: map[string]string{"limit": "10"},
+		form:  url.Values{"login": {"bob"}, "scope": {"read", "write"}, "note": {"null"}, "ids": {"1|2"}},
+	}, params))
+	a.Equal(Params{User: "bob", Remember: true, Scopes: []string{"read", "write"}, IDs: &[]int{1, 2}, Limit: 10}, *params)
+
+	err := populate(&mockGetters{form: url.Values{"user": {"b"}, "scope": {"delete"}}}, &Params{})
+	a.Equal(ParamErrors{
+		{Name: "user", In: field.InForm, Reason: `value must be at least 2 characters long: "b"`},
+		{Name: "scope", In: field.InForm, Reason: `value must be one of read|write: "delete"`},
+		{Name: "note", In: field.InForm, Reason: "required value is missing"},
+	}, err)
+
+	// Form params are not read from the query
+	err = populate(&mockGetters{query: map[string]string{"user": "bob", "scope": "read", "note": "x"}}, &Params{})
+	a.Len(err, 3)
+}
+
+func TestGenerateParseForm(t *testing.T) {
+	type Params struct {
+		User string `form:"user"`
+	}
+	a := assert.New(t)
+	a.Nil(GenerateParseForm[struct {
+		Limit int `query:"limit"`
+	}]())
+	a.Nil(GenerateParseForm[types.None]())
+
+	parseForm := GenerateParseForm[Params]()
+	a.NotNil(parseForm)
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("user=%zz"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	a.EqualError(parseForm(r), `invalid URL escape "%zz"`)
+	a.NoError(parseForm((&mockGetters{form: url.Values{"user": {"bob"}}}).Request()))
+}
+
+func TestGenerate_constraints(t *testing.T) {
+	type Params struct {
+		Limit int      `query:"limit,,default=20,min=1,max=100"`
+		Sort  string   `query:"sort,,enum=asc|desc"`
+		Name  *string  `header:"X-Name,,pattern=^[a-z]+$"`
+		IDs   []uint16 `query:"ids,,multipleOf=2"`
+		Valid string   `query:"valid,,minLength=1,maxLength=3"`
+	}
+	a := assert.New(t)
+	populate := Generate[Params]()
+	getters := &mockGetters{
+		query:    map[string]string{"limit": "0", "sort": "up", "valid": "abc"},
+		queryArr: map[string][]string{"ids": {"2", "3"}},
+		headers:  map[string]string{"X-Name": "Bob"},
+	}
+	params := &Params{}
+	err := populate(getters, params)
+	a.Equal(ParamErrors{
+		{Name: "limit", In: field.InQuery, Reason: `value must be greater than or equal to 1: "0"`},
+		{Name: "sort", In: field.InQuery, Reason: `value must be one of asc|desc: "up"`},
+		{Name: "X-Name", In: field.InHeader, Reason: `value must match the pattern "^[a-z]+$": "Bob"`},
+		{Name: "ids", In: field.InQuery, Reason: `value must be a multiple of 2: "3"`},
+	}, err)
+	a.Equal(Params{Valid: "abc"}, *params)
+}
+
+func TestGenerate_embedded(t *testing.T) {
+	type Pagination struct {
+		Limit  int  `query:"limit,,default=20"`
+		Offset *int `query:"offset"`
+	}
+	type TenantHeaders struct {
+		Tenant string `header:"X-Tenant"`
+	}
+	type Params struct {
+		Pagination
+		*TenantHeaders
+		ID int `path:"id"`
+	}
+	a := assert.New(t)
+	populate := Generate[Params]()
+	getters := &mockGetters{
+		path:    map[string]string{"id": "7"},
+		query:   map[string]string{"offset": "40"},
+		headers: map[string]string{"X-Tenant": "acme"},
+	}
+	params := &Params{}
+	a.NoError(populate(getters, params))
+	a.Equal(Params{
+		Pagination:    Pagination{Limit: 20, Offset: lo.ToPtr(40)},
+		TenantHeaders: &TenantHeaders{Tenant: "acme"},
+		ID:            7,
+	}, *params)
+
+	err := populate(&mockGetters{path: map[string]string{"id": "7"}}, &Params{})
+	a.Equal(ParamErrors{{Name: "X-Tenant", In: field.InHeader, Reason: "required value is missing"}}, err)
+}
+
+func TestGenerate_objects(t *testing.T) {
+	type FilterParams struct {
+		Status string  `query:"status"`
+		Owner  *string `query:"owner"`
+		Limit  int     `query:"limit,,default=10"`
+	}
+	type Params struct {
+		Filter FilterParams `query:"filter"`
+		Sort   FilterParams `query:"sort,,separator=."`
+	}
+	a := assert.New(t)
+	populate := Generate[Params]()
+	getters := &mockGetters{query: map[string]string{
+		"filter[status]": "open", "filter[owner]": "bob",
+		"sort.status": "closed", "sort.limit": "5",
+	}}
+	params := &Params{}
+	a.NoError(populate(getters, params))
+	a.Equal(Params{
+		Filter: FilterParams{Status: "open", Owner: lo.ToPtr("bob"), Limit: 10},
+		Sort:   FilterParams{Status: "closed", Limit: 5},
+	}, *params)
+
+	err := populate(&mockGetters{query: map[string]string{"filter[status]": "open"}}, &Params{})
+	a.Equal(ParamErrors{{Name: "sort.status", In: field.InQuery, Reason: "required value is missing"}}, err)
+}
+
+func TestGenerate_maps(t *testing.T) {
+	type Env string
+	type Params struct {
+		Labels map[string]string        `query:"label."`
+		Meta   map[string]int           `header:"x-meta-,,min=0"`
+		Prefs  map[string]bool          `cookie:"pref_"`
+		IPs    map[string]netip.Addr    `query:"ip."`
+		Envs   map[string]Env           `query:"env."`
+		Empty  map[string]time.Duration `query:"timeout."`
+	}
+	a := assert.New(t)
+	populate := Generate[Params]()
+	getters := &mockGetters{
+		query:    map[string]string{"label.env": "prod", "label.team": "core", "label.": "ignored", "ip.home": "::1", "env.a": "dev"},
+		queryArr: map[string][]string{"label.multi": {"first", "second"}},
+		headers:  map[string]string{"X-Meta-Count": "2", "X-Other": "3"},
+		cookies:  map[string]*http.Cookie{"pref_dark": {Name: "pref_dark", Value: "true"}},
+	}
+	params := &Params{}
+	a.NoError(populate(getters, params))
+	a.Equal(Params{
+		Labels: map[string]string{"env": "prod", "team": "core", "multi": "first"},
+		Meta:   map[string]int{"Count": 2},
+		Prefs:  map[string]bool{"dark": true},
+		IPs:    map[string]netip.Addr{"home": netip.MustParseAddr("::1")},
+		Envs:   map[string]Env{"a": "dev"},
+	}, *params)
+
+	err := populate(&mockGetters{headers: map[string]string{"X-Meta-Count": "-1"}}, &Params{})
+	a.Equal(ParamErrors{{Name: "X-Meta-Count", In: field.InHeader, Reason: `value must be greater than or equal to 0: "-1"`}}, err)
+
+	params = &Params{}
+	err = populate(&mockGetters{headers: map[string]string{"X-Meta-B": "-2", "X-Meta-A": "-1", "X-Meta-C": "3"}}, params)
+	a.Equal(ParamErrors{
+		{Name: "X-Meta-A", In: field.InHeader, Reason: `value must be greater than or equal to 0: "-1"`},
+		{Name: "X-Meta-B", In: field.InHeader, Reason: `value must be greater than or equal to 0: "-2"`},
+	}, err)
+	a.Nil(params.Meta)
+}
+
+func TestGenerate_missingRequired(t *testing.T) {
+	type Params struct {
+		Path      *int   `path:"id"`
+		Query     int    `query:"q"`
+		Header    int    `header:"X-Count"`
+		Cookie    string `cookie:"session"`
+		Slice     []int  `query:"ids"`
+		Optional  *int   `header:"X-Optional"`
+		Defaulted int    `cookie:"defaulted,,default=1"`
+	}
+	a := assert.New(t)
+	populate := Generate[Params]()
+	params := &Params{}
+	err := populate(&mockGetters{}, params)
+	a.Equal(ParamErrors{
+		{Name: "id", In: field.InPath, Reason: "required value is missing"},
+		{Name: "q", In: field.InQuery, Reason: "required value is missing"},
+		{Name: "X-Count", In: field.InHeader, Reason: "required value is missing"},
+		{Name: "session", In: field.InCookie, Reason: "required value is missing"},
+		{Name: "ids", In: field.InQuery, Reason: "required value is missing"},
+	}, err)
+	a.Equal(Params{Defaulted: 1}, *params)
+}
+
 func Test_fieldPopulator(t *testing.T) {
 	type Params struct {
 		Field0 int                  `path:"foo"`
@@ -90,8 +501,7 @@ func Test_fieldPopulator(t *testing.T) {
 			a := assert.New(t)
 			params := Params{}
 			paramsV := reflect.ValueOf(&params).Elem()
-			field := paramsT.Field(tt.fieldIndex)
-			gotFn := fieldPopulator[Params](field)
+			gotFn := fieldPopulator[Params](field.Parse(paramsT.Field(tt.fieldIndex)))
 			a.NotNil(gotFn)
 			gotFn(getters, &params)
 			a.Equal(tt.want, paramsV.Field(tt.fieldIndex).Interface())
@@ -200,16 +610,17 @@ func Test_getPathValue(t *testing.T) {
 		name     string
 		argsName string
 		want     string
+		wantOk   bool
 	}{
-		{"exists", "foo", "bar"},
-		// {"not exists", "baz", ""}, // Path params are always required, and thus should always be present
+		{"exists", "foo", "bar", true},
+		{"not exists", "baz", "", false}, // e.g. a path param name that does not match the route pattern
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			a := assert.New(t)
 			got, gotOk := getPathValue(getters, tt.argsName)
 			a.Equal(tt.want, got)
-			a.True(gotOk)
+			a.Equal(tt.wantOk, gotOk)
 		})
 	}
 }
@@ -257,7 +668,7 @@ func Test_setFieldValueNull(t *testing.T) {
 			// Init params with non-null values to test nullification
 			params := Params{Field0: types.Nullable[int](lo.ToPtr(123)), Field1: lo.ToPtr(types.Nullable[int](lo.ToPtr(123)))}
 			paramsV := reflect.ValueOf(&params).Elem()
-			fieldPtr := getFieldPtr(&params, paramsT.Field(tt.argsFieldIndex).Offset)
+			fieldPtr := getFieldPtr(&params, nil, paramsT.Field(tt.argsFieldIndex).Offset)
 			setFieldValueNull(fieldPtr, tt.argsIndirectionLevel)
 			if tt.argsIndirectionLevel == 1 {
 				a.Nil(paramsV.Field(tt.argsFieldIndex).Interface().(types.Nullable[int]))
@@ -295,7 +706,7 @@ func Test_setFn(t *testing.T) {
 			params := &Params{}
 			paramsElemV := reflect.ValueOf(params).Elem()
 			field := paramsT.Field(tt.fieldIndex)
-			fieldPtr := getFieldPtr(params, field.Offset)
+			fieldPtr := getFieldPtr(params, nil, field.Offset)
 			setFn[int](fieldPtr, tt.indirectionLevel, tt.value)
 			if tt.indirectionLevel == 0 {
 				a.Equal(tt.want, paramsElemV.Field(tt.fieldIndex).Interface())
@@ -334,17 +745,47 @@ func Test_getFieldPtr(t *testing.T) {
 			a := assert.New(t)
 			field := paramsT.Field(tt.fieldIndex)
 			fieldOffset := field.Offset
-			got := getFieldPtr(params, fieldOffset)
+			got := getFieldPtr(params, nil, fieldOffset)
 			a.Equal(uintptr(tt.want), uintptr(got), "getFieldPtr(%v, %v)", params, fieldOffset)
 		})
 	}
 }
 
+func Test_getFieldPtr_embeddings(t *testing.T) {
+	type Inner struct {
+		Bool bool
+		Int  int
+	}
+	type Middle struct {
+		Str string
+		*Inner
+	}
+	type Params struct {
+		Bool bool
+		Middle
+	}
+	a := assert.New(t)
+	params := &Params{}
+	middleT, innerT := reflect.TypeOf(Middle{}), reflect.TypeOf(Inner{})
+	embeddings := []field.Embedding{
+		{Offset: unsafe.Offsetof(params.Middle), Type: middleT},
+		{Offset: unsafe.Offsetof(params.Middle.Inner), Type: innerT, Ptr: true},
+	}
+	got := getFieldPtr(params, embeddings, unsafe.Offsetof(Inner{}.Int))
+	a.NotNil(params.Inner) // nil pointer embeddings are allocated
+	a.Equal(unsafe.Pointer(&params.Inner.Int), got)
+	a.Equal(got, getFieldPtr(params, embeddings, unsafe.Offsetof(Inner{}.Int)))
+}
+
 type mockGetters struct {
 	cookies map[string]*http.Cookie
 	headers map[string]string
-	path    map[string]string
-	query   map[string]string
+	// headerLines are repeated header lines, only sent in the Request.
+	headerLines map[string][]string
+	path        map[string]string
+	query       map[string]string
+	queryArr    map[string][]string
+	form        url.Values // The fields of an application/x-www-form-urlencoded POST body, if set.
 }
 
 func (mg *mockGetters) Cookie(name string) (*http.Cookie, error) {
@@ -371,6 +812,37 @@ func (mg *mockGetters) QueryParam(name string) string {
 	return mg.query[name]
 }
 
+func (mg *mockGetters) QueryParamArr(name string) []string {
+	return mg.queryArr[name]
+}
+
 func (mg *mockGetters) HasQueryParam(name string) bool {
-	return mg.query[name] != ""
+	return mg.query[name] != "" || len(mg.queryArr[name]) > 0
+}
+
+func (mg *mockGetters) Request() *http.Request {
+	query := url.Values{}
+	for name, value := range mg.query {
+		query.Add(name, value)
+	}
+	for name, values := range mg.queryArr {
+		query[name] = append(query[name], values...)
+	}
+	r := httptest.NewRequest(http.MethodGet, "/?"+query.Encode(), nil)
+	if mg.form != nil {
+		r = httptest.NewRequest(http.MethodPost, "/?"+query.Encode(), strings.NewReader(mg.form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	for name, value := range mg.headers {
+		r.Header.Set(name, value)
+	}
+	for name, values := range mg.headerLines {
+		for _, value := range values {
+			r.Header.Add(name, value)
+		}
+	}
+	for _, cookie := range mg.cookies {
+		r.AddCookie(cookie)
+	}
+	return r
 }
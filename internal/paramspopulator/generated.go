@@ -0,0 +1,124 @@
+package paramspopulator
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/crunk1/xfuego/internal/field"
+)
+
+// generated maps the params struct types to their populate functions generated by xfuego-gen, see Register.
+var generated sync.Map
+
+type generatedFn struct {
+	populate    any // func(ContextGetters, *ReqParamsT) error
+	fingerprint string
+}
+
+// Register registers the populate function of ReqParamsT generated by xfuego-gen, which Generate returns instead of
+// populating ReqParamsT with reflection. fingerprint is the Fingerprint of the ReqParamsT fields it was generated from.
+func Register[ReqParamsT any](populate func(ContextGetters, *ReqParamsT) error, fingerprint string) {
+	generated.Store(reflect.TypeFor[ReqParamsT](), generatedFn{populate: populate, fingerprint: fingerprint})
+}
+
+// CheckGenerated returns a *field.StructError if the registered populate function of ReqParamsT was generated from
+// another version of ReqParamsT, which is then populated with reflection. It is called at route registration.
+func CheckGenerated[ReqParamsT any]() error {
+	t := reflect.TypeFor[ReqParamsT]()
+	if _, upToDate := generatedPopulator[ReqParamsT](t); !upToDate {
+		return &field.StructError{Type: t, Errors: []string{"the code generated by xfuego-gen is out of date, run go generate"}}
+	}
+	return nil
+}
+
+// generatedPopulator returns the registered populate function of ReqParamsT, or nil, and whether it is up to date. An
+// out-of-date function, generated from another version of ReqParamsT, is not returned.
+func generatedPopulator[ReqParamsT any](t reflect.Type) (populate func(ContextGetters, *ReqParamsT) error, upToDate bool) {
+	v, ok := generated.Load(t)
+	if !ok {
+		return nil, true
+	}
+	fn := v.(generatedFn)
+	fields := make([]reflect.StructField, t.NumField())
+	for i := range fields {
+		fields[i] = t.Field(i)
+	}
+	if fn.fingerprint != Fingerprint(fields) {
+		return nil, false
+	}
+	return fn.populate.(func(ContextGetters, *ReqParamsT) error), true
+}
+
+// Fingerprint describes the fields of a params struct that its populate function depends on: the name, type and tag of
+// its param fields, and its embedded fields.
+func Fingerprint(fields []reflect.StructField) string {
+	var b strings.Builder
+	for _, f := range fields {
+		if !f.Anonymous && !hasParamTag(f.Tag) {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(f.Name + " " + f.Type.String() + " " + string(f.Tag))
+	}
+	return b.String()
+}
+
+func hasParamTag(tag reflect.StructTag) bool {
+	for _, key := range []string{"query", "path", "header", "cookie", "form"} {
+		if _, ok := tag.Lookup(key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// The getters of the populate functions generated by xfuego-gen, which know the location of each param.
+
+func QueryValue(c ContextGetters, name string) (string, bool) {
+	return getQueryValue(c, name)
+}
+
+func PathValue(c ContextGetters, name string) (string, bool) {
+	return getPathValue(c, name)
+}
+
+func HeaderValue(c ContextGetters, name string) (string, bool) {
+	return getHeaderValue(c, name)
+}
+
+func CookieValue(c ContextGetters, name string) (string, bool) {
+	return getCookieValue(c, name)
+}
+
+func FormValue(c ContextGetters, name string) (string, bool) {
+	return getFormValue(c, name)
+}
+
+func QueryValues(c ContextGetters, name string) ([]string, bool) {
+	return getQueryValues(c, name)
+}
+
+func HeaderValues(c ContextGetters, name string) ([]string, bool) {
+	return getHeaderValues(c, name)
+}
+
+func CookieValues(c ContextGetters, name string) ([]string, bool) {
+	return getCookieValues(c, name)
+}
+
+func FormValues(c ContextGetters, name string) ([]string, bool) {
+	return getFormValues(c, name)
+}
+
+// SplitLists splits the delimited values of a slice param, see field.Param.Delimiter.
+func SplitLists(values []string, delimiter string) []string {
+	return splitLists(values, delimiter)
+}
+
+// NewParamError returns the ParamError of a param that could not be populated.
+func NewParamError(name string, in field.In, err error) ParamError {
+	return ParamError{Name: name, In: in, Reason: err.Error()}
+}
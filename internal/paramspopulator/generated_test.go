@@ -0,0 +1,76 @@
+package paramspopulator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/crunk1/xfuego/internal/field"
+)
+
+func TestGenerate_generated(t *testing.T) {
+	type Params struct {
+		Limit int `query:"limit"`
+		Other string
+	}
+	a := assert.New(t)
+	var called bool
+	Register(func(c ContextGetters, params *Params) error {
+		called = true
+		value, _ := QueryValue(c, "limit")
+		params.Other = value
+		return nil
+	}, `Limit int query:"limit"`)
+	defer generated.Delete(reflect.TypeFor[Params]())
+
+	populate := Generate[Params]()
+	params := &Params{}
+	a.NoError(populate(&mockGetters{query: map[string]string{"limit": "1"}}, params))
+	a.True(called)
+	a.Equal(Params{Other: "1"}, *params)
+}
+
+func TestCheckGenerated(t *testing.T) {
+	type Params struct {
+		Limit int `query:"limit,,default=1"`
+	}
+	a := assert.New(t)
+	a.NoError(CheckGenerated[Params]())
+
+	Register(func(ContextGetters, *Params) error { return nil }, `Limit int query:"limit,,default=1"`)
+	defer generated.Delete(reflect.TypeFor[Params]())
+	a.NoError(CheckGenerated[Params]())
+
+	Register(func(ContextGetters, *Params) error { return nil }, `Limit int query:"limit"`)
+	err := CheckGenerated[Params]()
+	a.EqualError(err, "invalid params struct paramspopulator.Params: the code generated by xfuego-gen is out of date, run go generate")
+	a.IsType(&field.StructError{}, err)
+
+	// Populated with reflection instead
+	params := &Params{}
+	a.NoError(Generate[Params]()(&mockGetters{}, params))
+	a.Equal(Params{Limit: 1}, *params)
+}
+
+func TestFingerprint(t *testing.T) {
+	type Embedded struct{}
+	type Params struct {
+		Embedded
+		Limit  *int     `query:"limit,,default=1"`
+		Tags   []string `header:"X-Tags"`
+		Other  string   `json:"other"`
+		ignore int
+	}
+	tt := reflect.TypeFor[Params]()
+	fields := make([]reflect.StructField, tt.NumField())
+	for i := range fields {
+		fields[i] = tt.Field(i)
+	}
+	assert.Equal(t, `Embedded paramspopulator.Embedded ; Limit *int query:"limit,,default=1"; Tags []string header:"X-Tags"`, Fingerprint(fields))
+}
+
+func TestNewParamError(t *testing.T) {
+	err := NewParamError("id", field.InPath, ErrMissing)
+	assert.Equal(t, ParamError{Name: "id", In: field.InPath, Reason: "required value is missing"}, err)
+}
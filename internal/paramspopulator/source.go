@@ -0,0 +1,132 @@
+package paramspopulator
+
+import (
+	"reflect"
+
+	"github.com/crunk1/xfuego/internal/field"
+	"github.com/crunk1/xfuego/internal/types"
+)
+
+// Source is how a param got its value when its params struct was populated.
+type Source uint8
+
+const (
+	SourceMissing   Source = iota // Not in the request, and without a default value.
+	SourceProvided                // In the request.
+	SourceDefaulted               // Not in the request, and set to its default value.
+	SourceNull                    // In the request as "null", for a nullable param.
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceProvided:
+		return "provided"
+	case SourceDefaulted:
+		return "defaulted"
+	case SourceNull:
+		return "null"
+	}
+	return "missing"
+}
+
+// GenerateSource returns a function that reports the Source of a ReqParamsT param in the request, and false if
+// ReqParamsT has no such param. The param is named as in its tag, e.g. "limit", or qualified with its location, e.g.
+// "query:limit", for params of different locations sharing a name; an unqualified name is the first such param.
+//
+// Sources are found from the request when asked for, so that populating params does not record them.
+func GenerateSource[ReqParamsT any]() func(c ContextGetters, name string) (Source, bool) {
+	if types.IsNoneType[ReqParamsT]() {
+		return func(ContextGetters, string) (Source, bool) { return SourceMissing, false }
+	}
+
+	sourceFns := map[string]func(c ContextGetters) Source{}
+	for _, p := range field.ParseStruct(reflect.TypeOf((*ReqParamsT)(nil)).Elem()) {
+		sourceFn := paramSource(p)
+		sourceFns[p.In.String()+":"+p.Name] = sourceFn
+		if _, ok := sourceFns[p.Name]; !ok {
+			sourceFns[p.Name] = sourceFn
+		}
+	}
+
+	return func(c ContextGetters, name string) (Source, bool) {
+		sourceFn, ok := sourceFns[name]
+		if !ok {
+			return SourceMissing, false
+		}
+		return sourceFn(c), true
+	}
+}
+
+// paramSource returns a function that reports the Source of a param in the request, as the populators find it.
+func paramSource(p field.Param) func(c ContextGetters) Source {
+	absent := SourceMissing
+	if p.DefaultValue != nil {
+		absent = SourceDefaulted
+	}
+	var presentFns []func(c ContextGetters) (valueStr string, ok bool)
+	for _, name := range append([]string{p.Name}, p.Aliases...) {
+		presentFns = append(presentFns, presentFn(p, name))
+	}
+	return func(c ContextGetters) Source {
+		for _, present := range presentFns {
+			if valueStr, ok := present(c); ok {
+				if p.Nullable && valueStr == "null" {
+					return SourceNull
+				}
+				return SourceProvided
+			}
+		}
+		return absent
+	}
+}
+
+// GenerateDeprecated returns a function that reports whether the request has a deprecated ReqParamsT param, or a
+// param sent under an alias, or nil if ReqParamsT has neither.
+func GenerateDeprecated[ReqParamsT any]() func(c ContextGetters) bool {
+	if types.IsNoneType[ReqParamsT]() {
+		return nil
+	}
+
+	var presentFns []func(c ContextGetters) (string, bool)
+	for _, p := range field.ParseStruct(reflect.TypeOf((*ReqParamsT)(nil)).Elem()) {
+		if p.Deprecated {
+			presentFns = append(presentFns, presentFn(p, p.Name))
+		}
+		for _, alias := range p.Aliases {
+			presentFns = append(presentFns, presentFn(p, alias))
+		}
+	}
+	if presentFns == nil {
+		return nil
+	}
+
+	return func(c ContextGetters) bool {
+		for _, present := range presentFns {
+			if _, ok := present(c); ok {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// presentFn returns a function that reports whether a param is sent under a name, and its value for scalar params.
+func presentFn(p field.Param, name string) func(c ContextGetters) (valueStr string, ok bool) {
+	switch {
+	case p.Map:
+		getFieldValuesFn := getMapFns[p.In]
+		return func(c ContextGetters) (string, bool) {
+			return "", len(getFieldValuesFn(c.Request(), name)) > 0
+		}
+	case p.Slice:
+		getFieldValuesFn := getSliceFns[p.In]
+		return func(c ContextGetters) (string, bool) {
+			_, ok := getFieldValuesFn(c, name)
+			return "", ok
+		}
+	}
+	getFieldValueFn := getFn(p)
+	return func(c ContextGetters) (string, bool) {
+		return getFieldValueFn(c, name)
+	}
+}
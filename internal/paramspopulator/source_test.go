@@ -0,0 +1,84 @@
+package paramspopulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/crunk1/xfuego/internal/types"
+)
+
+func TestGenerateSource(t *testing.T) {
+	type Params struct {
+		Limit    int                 `query:"limit,,default=20"`
+		Offset   *int                `query:"offset"`
+		Parent   types.Nullable[int] `query:"parent"`
+		Tags     []string            `query:"tag,,default=a|b"`
+		Labels   map[string]string   `query:"label."`
+		ID       string              `path:"id"`
+		HeaderID *string             `header:"id"`
+		Size     *int                `query:"size,,alias=page_size"`
+	}
+	getters := &mockGetters{
+		query:    map[string]string{"parent": "null", "label.env": "prod", "page_size": "10"},
+		queryArr: map[string][]string{"tag": {"x"}},
+		path:     map[string]string{"id": "42"},
+	}
+	source := GenerateSource[Params]()
+
+	type testCase struct {
+		name   string
+		want   Source
+		wantOk bool
+	}
+	tests := []testCase{
+		{"limit", SourceDefaulted, true},
+		{"offset", SourceMissing, true},
+		{"parent", SourceNull, true},
+		{"tag", SourceProvided, true},
+		{"label.", SourceProvided, true},
+		{"id", SourceProvided, true},
+		{"path:id", SourceProvided, true},
+		{"header:id", SourceMissing, true},
+		{"query:limit", SourceDefaulted, true},
+		{"size", SourceProvided, true},
+		{"unknown", SourceMissing, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := source(getters, tt.name)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGenerateSource_none(t *testing.T) {
+	_, ok := GenerateSource[types.None]()(&mockGetters{}, "limit")
+	assert.False(t, ok)
+}
+
+func TestSource_String(t *testing.T) {
+	assert.Equal(t, "missing", SourceMissing.String())
+	assert.Equal(t, "provided", SourceProvided.String())
+	assert.Equal(t, "defaulted", SourceDefaulted.String())
+	assert.Equal(t, "null", SourceNull.String())
+}
+
+func TestGenerateDeprecated(t *testing.T) {
+	type Params struct {
+		Limit int    `query:"limit,,alias=page_size"`
+		Sort  string `header:"X-Sort,,deprecated"`
+		Other string `query:"other"`
+	}
+	deprecated := GenerateDeprecated[Params]()
+	assert.False(t, deprecated(&mockGetters{query: map[string]string{"limit": "1", "other": "x"}}))
+	assert.True(t, deprecated(&mockGetters{query: map[string]string{"page_size": "1"}}))
+	assert.True(t, deprecated(&mockGetters{headers: map[string]string{"X-Sort": "asc"}}))
+
+	type NotDeprecated struct {
+		Limit int `query:"limit"`
+	}
+	assert.Nil(t, GenerateDeprecated[NotDeprecated]())
+	assert.Nil(t, GenerateDeprecated[types.None]())
+}
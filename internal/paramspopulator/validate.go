@@ -0,0 +1,72 @@
+package paramspopulator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/crunk1/xfuego/internal/field"
+	"github.com/crunk1/xfuego/internal/types"
+)
+
+// Validator is implemented by params structs with rules spanning several params, e.g. "from must be before to".
+type Validator interface {
+	Validate() error
+}
+
+// ContextValidator is the context-aware variant of Validator.
+type ContextValidator interface {
+	Validate(ctx context.Context) error
+}
+
+// GenerateValidate returns a function that calls the Validate method of a populated ReqParamsT struct, if it
+// implements Validator or ContextValidator.
+//
+// Validate may return a ParamError or ParamErrors to report field-scoped errors; their In location is filled in from
+// the param name if not set. Other errors are returned as is.
+func GenerateValidate[ReqParamsT any]() func(context.Context, *ReqParamsT) error {
+	var validate func(ctx context.Context, params *ReqParamsT) error
+	switch any((*ReqParamsT)(nil)).(type) {
+	case Validator:
+		validate = func(_ context.Context, params *ReqParamsT) error { return any(params).(Validator).Validate() }
+	case ContextValidator:
+		validate = func(ctx context.Context, params *ReqParamsT) error {
+			return any(params).(ContextValidator).Validate(ctx)
+		}
+	default:
+		return func(context.Context, *ReqParamsT) error { return nil }
+	}
+
+	// Param name -> location, to complete the ParamErrors returned by Validate.
+	ins := map[string]field.In{}
+	if !types.IsNoneType[ReqParamsT]() {
+		for _, p := range field.ParseStruct(reflect.TypeOf((*ReqParamsT)(nil)).Elem()) {
+			ins[p.Name] = p.In
+		}
+	}
+
+	return func(ctx context.Context, params *ReqParamsT) error {
+		err := validate(ctx, params)
+		if err == nil {
+			return nil
+		}
+		var paramErrs ParamErrors
+		var paramErr ParamError
+		var paramErrPtr *ParamError
+		if errors.As(err, &paramErrs) {
+			paramErrs = append(ParamErrors(nil), paramErrs...)
+		} else if errors.As(err, &paramErr) {
+			paramErrs = ParamErrors{paramErr}
+		} else if errors.As(err, &paramErrPtr) {
+			paramErrs = ParamErrors{*paramErrPtr}
+		} else {
+			return err
+		}
+		for i := range paramErrs {
+			if paramErrs[i].In == field.InNone {
+				paramErrs[i].In = ins[paramErrs[i].Name]
+			}
+		}
+		return paramErrs
+	}
+}
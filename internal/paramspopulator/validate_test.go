@@ -0,0 +1,75 @@
+package paramspopulator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/crunk1/xfuego/internal/field"
+	"github.com/crunk1/xfuego/internal/types"
+)
+
+type rangeParams struct {
+	From int `query:"from"`
+	To   int `query:"to"`
+}
+
+func (p rangeParams) Validate() error {
+	var errs ParamErrors
+	if p.From > p.To {
+		errs = append(errs, ParamError{Name: "from", Reason: "must not be after to"})
+	}
+	if p.To-p.From > 10 {
+		errs = append(errs, ParamError{Name: "to", Reason: "range is too large"}, ParamError{Name: "other", Reason: "unknown"})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+type identityParams struct {
+	UserID *int    `header:"X-User-ID"`
+	Email  *string `query:"email"`
+}
+
+type ctxKey struct{}
+
+func (p *identityParams) Validate(ctx context.Context) error {
+	if ctx.Value(ctxKey{}) != nil {
+		return errors.New("context error")
+	}
+	if (p.UserID == nil) == (p.Email == nil) {
+		return &ParamError{Name: "email", Reason: "exactly one of X-User-ID and email is required"}
+	}
+	return nil
+}
+
+func TestGenerateValidate(t *testing.T) {
+	a := assert.New(t)
+
+	validateRange := GenerateValidate[rangeParams]()
+	a.NoError(validateRange(context.Background(), &rangeParams{From: 1, To: 2}))
+	a.Equal(ParamErrors{
+		{Name: "from", In: field.InQuery, Reason: "must not be after to"},
+	}, validateRange(context.Background(), &rangeParams{From: 2, To: 1}))
+	err := validateRange(context.Background(), &rangeParams{From: 0, To: 20})
+	a.Equal(ParamErrors{
+		{Name: "to", In: field.InQuery, Reason: "range is too large"},
+		{Name: "other", Reason: "unknown"},
+	}, err)
+	a.EqualError(err, `query param "to": range is too large; param "other": unknown`)
+
+	validateIdentity := GenerateValidate[identityParams]()
+	a.NoError(validateIdentity(context.Background(), &identityParams{UserID: new(int)}))
+	a.Equal(ParamErrors{
+		{Name: "email", In: field.InQuery, Reason: "exactly one of X-User-ID and email is required"},
+	}, validateIdentity(context.Background(), &identityParams{}))
+	ctx := context.WithValue(context.Background(), ctxKey{}, true)
+	a.EqualError(validateIdentity(ctx, &identityParams{}), "context error")
+
+	a.NoError(GenerateValidate[types.None]()(context.Background(), new(types.None)))
+	a.NoError(GenerateValidate[struct{}]()(context.Background(), &struct{}{}))
+}
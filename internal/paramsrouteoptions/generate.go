@@ -1,75 +1,316 @@
 package paramsrouteoptions
 
 import (
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
 	"reflect"
+	"slices"
+	"strconv"
+	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-fuego/fuego"
+	"github.com/samber/lo"
 
 	"github.com/crunk1/xfuego/internal/field"
 	"github.com/crunk1/xfuego/internal/types"
 )
 
-func Generate[ReqParamsT any]() []func(*fuego.BaseRoute) {
+// Generate returns the route options documenting the params of ReqParamsT, and checks that its path params match the
+// route path, prefixed by the basePath of its server or group. It returns a *field.StructError listing every invalid
+// field. The params generated by xfuego-gen, see Register, are documented instead of parsing ReqParamsT.
+func Generate[ReqParamsT any](basePath string, path string) ([]func(*fuego.BaseRoute), error) {
 	// No params -> no-op
 	if types.IsNoneType[ReqParamsT]() {
-		return nil
+		return nil, nil
 	}
 
 	// Check params is a struct.
 	t := reflect.TypeOf((*ReqParamsT)(nil)).Elem()
 	if t.Kind() != reflect.Struct {
-		panic("ReqParamsT type must be a struct: type=" + t.String())
+		return nil, &field.StructError{Type: t, Errors: []string{"ReqParamsT type must be a struct"}}
 	}
 
+	var errs []string
+	params, ok := generatedParams(t)
+	if !ok {
+		var err error
+		params, err = field.TryParseStruct(t)
+		var structErr *field.StructError
+		if errors.As(err, &structErr) {
+			errs = append(errs, structErr.Errors...)
+		}
+	}
+	errs = append(errs, checkPath(basePath, path, params)...)
 	var opts []func(*fuego.BaseRoute)
-	for i := 0; i < t.NumField(); i++ {
-		opt := parsedFieldToRouteOption(field.Parse(t.Field(i)))
-		if opt == nil {
+	var formParams []field.Param
+	for i, p := range params {
+		var opt func(*fuego.BaseRoute)
+		var errMsg string
+		if p.In == field.InForm {
+			// The form params are documented together, as the request body.
+			formParams = append(formParams, p)
+			continue
+		} else if p.Object == nil || p.Object.Separator != "" {
+			opt, errMsg = tryRouteOption(p.Field.Name, func() func(*fuego.BaseRoute) { return parsedFieldToRouteOption(p) })
+		} else if i == 0 || params[i-1].Object != p.Object {
+			// The params of a deepObject are contiguous, and documented as a single object param.
+			objectParams := lo.Filter(params, func(q field.Param, _ int) bool { return q.Object == p.Object })
+			opt, errMsg = tryRouteOption(p.Object.Name, func() func(*fuego.BaseRoute) { return objectRouteOption(p.Object, objectParams) })
+		} else {
+			continue
+		}
+		if errMsg != "" {
+			errs = append(errs, errMsg)
 			continue
 		}
 		opts = append(opts, opt)
 	}
-	return opts
+	if formParams != nil {
+		opt, errMsg := tryRouteOption(formParams[0].Field.Name, func() func(*fuego.BaseRoute) { return formRouteOption(formParams) })
+		if errMsg != "" {
+			errs = append(errs, errMsg)
+		} else {
+			opts = append(opts, opt)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, &field.StructError{Type: t, Errors: errs}
+	}
+	return opts, nil
+}
+
+// tryRouteOption builds a param route option and applies it to a scratch route, returning the message of any panic,
+// e.g. fuego rejecting a default value, instead of panicking.
+func tryRouteOption(fieldName string, build func() func(*fuego.BaseRoute)) (opt func(*fuego.BaseRoute), errMsg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			opt, errMsg = nil, fmt.Sprint(r)+": field="+fieldName
+		}
+	}()
+	opt = build()
+	opt(&fuego.BaseRoute{Operation: openapi3.NewOperation()})
+	return opt, ""
+}
+
+// objectRouteOption returns the route option declaring a nested struct param with style deepObject, as an object
+// param whose properties are the nested struct's params.
+func objectRouteOption(o *field.Object, params []field.Param) func(*fuego.BaseRoute) {
+	schema := openapi3.NewObjectSchema()
+	required := false
+	for _, p := range params {
+		name := o.PropertyName(p.Name)
+		schema.WithPropertyRef(name, paramSchema(p))
+		if p.Required {
+			schema.Required = append(schema.Required, name)
+			required = true
+		}
+	}
+	// The object param is not declared as required to fuego, which would look for a "filter" query param: the
+	// nested params are checked on their own.
+	opt := fuego.OptionParam(o.Name, fuego.ParamDescription(o.Desc), paramIn(fuego.QueryParamType), paramGoType(openapi3.TypeObject))
+	return withParam(opt, field.InQuery, o.Name, func(param *openapi3.Parameter) {
+		param.Style, param.Explode = field.StyleDeepObject, lo.ToPtr(true)
+		param.Required = required
+		param.Schema = schema.NewRef()
+	})
+}
+
+// formRouteOption returns the route option declaring the form params as the application/x-www-form-urlencoded request
+// body, an object whose properties are the params. The serialization of slice params is the encoding of their
+// property; aliases are documented as deprecated properties.
+func formRouteOption(params []field.Param) func(*fuego.BaseRoute) {
+	schema := openapi3.NewObjectSchema()
+	encoding := map[string]*openapi3.Encoding{}
+	for _, p := range params {
+		property := p
+		property.In, property.Aliases = field.InQuery, nil // documented with the query param schema
+		names := append([]string{p.Name}, p.Aliases...)
+		for i, name := range names {
+			if i > 0 {
+				property.Desc = "Deprecated alias of " + p.Name + "."
+				property.Deprecated, property.DefaultValue = true, nil
+			}
+			property.Name = name
+			schema.WithPropertyRef(name, paramSchema(property))
+			if p.Slice {
+				encoding[name] = &openapi3.Encoding{Style: p.Style, Explode: p.Explode}
+			}
+		}
+		if p.Required {
+			schema.Required = append(schema.Required, p.Name)
+		}
+	}
+	mediaType := openapi3.NewMediaType().WithSchema(schema)
+	if len(encoding) > 0 {
+		mediaType.Encoding = encoding
+	}
+	body := openapi3.NewRequestBody().
+		WithRequired(len(schema.Required) > 0).
+		WithContent(openapi3.Content{"application/x-www-form-urlencoded": mediaType})
+	return func(r *fuego.BaseRoute) {
+		r.Operation.RequestBody = &openapi3.RequestBodyRef{Value: body}
+	}
 }
 
-func parsedFieldToRouteOption(in field.In, goKind reflect.Kind, required bool, nullable bool, _ func(string) any, name string, desc string, defaultValue any, examples map[string]any) func(*fuego.BaseRoute) {
-	if in == field.InNone {
+// mapRouteOption returns the route option declaring a map param, as an object param named by the map prefix whose
+// additionalProperties are the map values.
+func mapRouteOption(p field.Param) func(*fuego.BaseRoute) {
+	valueParam := p
+	valueParam.Map, valueParam.Desc, valueParam.Deprecated = false, "", false
+	schema := openapi3.NewObjectSchema().WithAdditionalProperties(paramSchema(valueParam).Value)
+	schema.Description = "All the " + p.In.String() + " params prefixed with " + strconv.Quote(p.Name) + ", keyed by their unprefixed names."
+	opt := fuego.OptionParam(p.Name, fuego.ParamDescription(p.Desc), paramIn(fuegoParamTypes[p.In]), paramGoType(openapi3.TypeObject))
+	return withParam(opt, p.In, p.Name, func(param *openapi3.Parameter) {
+		param.Deprecated = p.Deprecated
+		param.Schema = schema.NewRef()
+	})
+}
+
+// aliasedRouteOption returns the route option declaring a param and its aliases, which are documented as deprecated
+// params.
+func aliasedRouteOption(p field.Param) func(*fuego.BaseRoute) {
+	canonical := p
+	canonical.Aliases = nil
+	opts := []func(*fuego.BaseRoute){parsedFieldToRouteOption(canonical)}
+	for _, alias := range p.Aliases {
+		aliasParam := canonical
+		aliasParam.Name, aliasParam.Desc = alias, "Deprecated alias of "+p.Name+"."
+		aliasParam.Deprecated, aliasParam.DefaultValue, aliasParam.Required = true, nil, false
+		opts = append(opts, parsedFieldToRouteOption(aliasParam))
+	}
+	return func(r *fuego.BaseRoute) {
+		for _, opt := range opts {
+			opt(r)
+		}
+	}
+}
+
+// paramSchema returns the OpenAPI schema of a param, with the param description.
+func paramSchema(p field.Param) *openapi3.SchemaRef {
+	route := &fuego.BaseRoute{Operation: openapi3.NewOperation()}
+	parsedFieldToRouteOption(p)(route)
+	param := route.Operation.Parameters[0].Value
+	if param.Description != "" {
+		param.Schema.Value.Description = param.Description
+	}
+	param.Schema.Value.Deprecated = param.Deprecated
+	return param.Schema
+}
+
+// parsedFieldToRouteOption returns the route option declaring a param. Required params are only documented as required,
+// not declared so to fuego: fuego.ValidateParams would reject the requests missing one before xfuego populates the
+// params, which reports every missing and invalid param at once.
+func parsedFieldToRouteOption(p field.Param) func(*fuego.BaseRoute) {
+	if p.In == field.InNone {
 		return nil
 	}
 
-	// param opts: required, default, examples, nullable
+	if p.Aliases != nil {
+		return aliasedRouteOption(p)
+	}
+
+	// param opts: default, examples, nullable
 	var paramOpts []func(param *fuego.OpenAPIParam)
-	if required {
-		paramOpts = append(paramOpts, fuego.ParamRequired())
-	} else if defaultValue != nil {
-		paramOpts = append(paramOpts, fuego.ParamDefault(defaultValue))
+	if p.DefaultValue != nil {
+		paramOpts = append(paramOpts, fuego.ParamDefault(openAPIValue(p, p.DefaultValue)))
 	}
-	if nullable {
+	if p.Nullable {
 		paramOpts = append(paramOpts, fuego.ParamNullable())
 	}
-	for exampleName, exampleValue := range examples {
-		paramOpts = append(paramOpts, fuego.ParamExample(exampleName, exampleValue))
+	for exampleName, exampleValue := range p.Examples {
+		paramOpts = append(paramOpts, fuego.ParamExample(exampleName, openAPIValue(p, exampleValue)))
 	}
 
+	// Text types are strings as far as OpenAPI is concerned, unless they provide their own schema.
+	goKind := p.GoKind
+	var providedSchema *openapi3.Schema
+	if p.Text {
+		goKind = reflect.String
+		providedSchema = types.ParamSchema(p.GoType)
+		if p.Layout != "" {
+			// A custom time layout is no longer an OpenAPI date-time/date.
+			providedSchema = openapi3.NewStringSchema()
+			providedSchema.Description = "Go time layout: " + p.Layout
+		}
+	}
+
+	if p.Map {
+		return mapRouteOption(p)
+	}
+	if p.Slice {
+		return withParam(paramRouteOption(p.In, reflect.Slice, p.Name, p.Desc, paramOpts), p.In, p.Name, func(param *openapi3.Parameter) {
+			param.Style, param.Explode = p.Style, p.Explode
+			param.Required, param.Deprecated = p.Required, p.Deprecated
+			items := openapi3.NewSchema()
+			items.Type = &openapi3.Types{openAPITypes[goKind]}
+			setSchemaFormat(items, goKind)
+			if providedSchema != nil {
+				provided := *providedSchema
+				items = &provided
+			}
+			setSchemaConstraints(items, p)
+			param.Schema.Value.Items = items.NewRef()
+		})
+	}
+	return withParam(paramRouteOption(p.In, goKind, p.Name, p.Desc, paramOpts), p.In, p.Name, func(param *openapi3.Parameter) {
+		param.Required = p.Required || p.In == field.InPath
+		param.Deprecated = p.Deprecated
+		setSchemaFormat(param.Schema.Value, goKind)
+		if providedSchema != nil {
+			provided := *providedSchema
+			provided.Default, provided.Nullable = param.Schema.Value.Default, param.Schema.Value.Nullable
+			param.Schema = provided.NewRef()
+		}
+		setSchemaConstraints(param.Schema.Value, p)
+	})
+}
+
+// setSchemaConstraints sets the OpenAPI validation keywords of the param's validation constraints.
+// For slices, schema is the items schema.
+func setSchemaConstraints(schema *openapi3.Schema, p field.Param) {
+	if p.Min != nil {
+		schema.Min = p.Min
+	}
+	if p.Max != nil {
+		schema.Max = p.Max
+	}
+	if p.MultipleOf != nil {
+		schema.MultipleOf = p.MultipleOf
+	}
+	if p.MinLength != nil {
+		schema.MinLength = *p.MinLength
+	}
+	if p.MaxLength != nil {
+		schema.MaxLength = p.MaxLength
+	}
+	if p.Pattern != nil {
+		schema.Pattern = p.Pattern.String()
+	}
+	if p.Enum != nil {
+		schema.Enum = lo.Map(p.Enum, func(value any, _ int) any { return openAPIValue(p, value) })
+	}
+}
+
+// paramRouteOption returns the fuego route option declaring the param with its OpenAPI type.
+func paramRouteOption(in field.In, goKind reflect.Kind, name string, desc string, paramOpts []func(*fuego.OpenAPIParam)) func(*fuego.BaseRoute) {
 	// Query options. Has special handling for types.
 	if in == field.InQuery {
 		if goKind == reflect.String {
 			return fuego.OptionQuery(name, desc, paramOpts...)
-		} else if goKind == reflect.Int {
+		} else if isInt(goKind) || isUint(goKind) {
 			return fuego.OptionQueryInt(name, desc, paramOpts...)
 		} else if goKind == reflect.Bool {
 			return fuego.OptionQueryBool(name, desc, paramOpts...)
+		} else if isFloat(goKind) || goKind == reflect.Slice {
+			return fuego.OptionParam(name, append(paramOpts, fuego.ParamDescription(desc), paramIn(fuego.QueryParamType), paramGoType(openAPITypes[goKind]))...)
 		}
 	}
 
 	// Path, Header, Cookie options.
-	if goKind == reflect.String {
-		paramOpts = append(paramOpts, fuego.ParamString())
-	} else if goKind == reflect.Int {
-		paramOpts = append(paramOpts, fuego.ParamInteger())
-	} else if goKind == reflect.Bool {
-		paramOpts = append(paramOpts, fuego.ParamBool())
-	}
+	paramOpts = append(paramOpts, paramGoType(openAPITypes[goKind]))
 	if in == field.InPath {
 		return fuego.OptionPath(name, desc, paramOpts...)
 	} else if in == field.InHeader {
@@ -81,3 +322,155 @@ func parsedFieldToRouteOption(in field.In, goKind reflect.Kind, required bool, n
 	// Shouldn't reach here, but I wanted to be explicit in the if statements above - i.e. no catch-all `else` case
 	return nil
 }
+
+// withParam wraps a param route option so that the param's OpenAPI definition can be adjusted after fuego builds it.
+// fuego's param options only cover the OpenAPI type, so anything beyond that (format, bounds, style, etc.) is set here.
+// A param with the same location and name already declared on the route, e.g. by the options of its fuego.Group, is
+// replaced.
+func withParam(opt func(*fuego.BaseRoute), in field.In, name string, fn func(*openapi3.Parameter)) func(*fuego.BaseRoute) {
+	if opt == nil {
+		return nil
+	}
+	return func(r *fuego.BaseRoute) {
+		r.Operation.Parameters = slices.DeleteFunc(r.Operation.Parameters, func(param *openapi3.ParameterRef) bool {
+			return param.Value != nil && param.Value.In == string(fuegoParamTypes[in]) && param.Value.Name == name
+		})
+		opt(r)
+		param := r.Operation.Parameters.GetByInAndName(string(fuegoParamTypes[in]), name)
+		if param == nil || param.Schema == nil || param.Schema.Value == nil {
+			return
+		}
+		fn(param)
+	}
+}
+
+// setSchemaFormat sets the OpenAPI format of a numeric schema.
+func setSchemaFormat(schema *openapi3.Schema, goKind reflect.Kind) {
+	schema.Format = openAPIFormats[goKind]
+	if isUint(goKind) {
+		schema.WithMin(0)
+		if max, ok := openAPIUintMax[goKind]; ok {
+			schema.WithMax(max)
+		}
+	}
+}
+
+var fuegoParamTypes = map[field.In]fuego.ParamType{
+	field.InQuery:  fuego.QueryParamType,
+	field.InPath:   fuego.PathParamType,
+	field.InHeader: fuego.HeaderParamType,
+	field.InCookie: fuego.CookieParamType,
+}
+
+// openAPITypes maps each supported kind to its OpenAPI type.
+var openAPITypes = map[reflect.Kind]string{
+	reflect.Bool:    openapi3.TypeBoolean,
+	reflect.Int:     openapi3.TypeInteger,
+	reflect.Int8:    openapi3.TypeInteger,
+	reflect.Int16:   openapi3.TypeInteger,
+	reflect.Int32:   openapi3.TypeInteger,
+	reflect.Int64:   openapi3.TypeInteger,
+	reflect.Uint:    openapi3.TypeInteger,
+	reflect.Uint8:   openapi3.TypeInteger,
+	reflect.Uint16:  openapi3.TypeInteger,
+	reflect.Uint32:  openapi3.TypeInteger,
+	reflect.Uint64:  openapi3.TypeInteger,
+	reflect.Float32: openapi3.TypeNumber,
+	reflect.Float64: openapi3.TypeNumber,
+	reflect.String:  openapi3.TypeString,
+	reflect.Slice:   openapi3.TypeArray,
+}
+
+// openAPIFormats maps each numeric kind to its OpenAPI format.
+// OpenAPI has no unsigned formats, so unsigned kinds use the smallest signed format that holds them, bounded by
+// minimum=0 and their maximum, see openAPIUintMax. No format holds uint and uint64, which only have minimum=0.
+var openAPIFormats = map[reflect.Kind]string{
+	reflect.Int:     "int64",
+	reflect.Int8:    "int32",
+	reflect.Int16:   "int32",
+	reflect.Int32:   "int32",
+	reflect.Int64:   "int64",
+	reflect.Uint8:   "int32",
+	reflect.Uint16:  "int32",
+	reflect.Uint32:  "int64",
+	reflect.Float32: "float",
+	reflect.Float64: "double",
+}
+
+// openAPIUintMax maps the unsigned kinds that have an OpenAPI format to their maximum.
+var openAPIUintMax = map[reflect.Kind]float64{
+	reflect.Uint8:  math.MaxUint8,
+	reflect.Uint16: math.MaxUint16,
+	reflect.Uint32: math.MaxUint32,
+}
+
+// openAPIValue converts a default/example value to the Go type fuego expects for its OpenAPI type.
+// fuego requires integer param values to be an `int`, so the other integer kinds are converted: the unsigned tag values
+// that would overflow are rejected when parsing the param.
+// Named types are converted to their underlying builtin type, text types to their text representation, and slice
+// values ([]any) element-wise.
+func openAPIValue(p field.Param, value any) any {
+	if values, ok := value.([]any); ok {
+		return lo.Map(values, func(v any, _ int) any { return openAPIValue(p, v) })
+	}
+	if p.Text {
+		if t, ok := value.(time.Time); ok && p.Layout != "" {
+			return t.Format(p.Layout)
+		} else if d, ok := value.(types.Date); ok && p.Layout != "" {
+			return d.Format(p.Layout)
+		}
+		return textValue(value)
+	}
+	v := reflect.ValueOf(value)
+	if isInt(v.Kind()) {
+		return int(v.Int())
+	} else if isUint(v.Kind()) {
+		return int(v.Uint())
+	} else if isFloat(v.Kind()) {
+		return v.Float()
+	} else if v.Kind() == reflect.Bool {
+		return v.Bool()
+	} else if v.Kind() == reflect.String {
+		return v.String()
+	}
+	return value
+}
+
+// textValue returns the text representation of a text type value, via encoding.TextMarshaler or fmt.Stringer if
+// implemented.
+func textValue(value any) string {
+	if marshaler, ok := value.(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			panic("param value cannot be marshaled to text: " + err.Error())
+		}
+		return string(text)
+	} else if stringer, ok := value.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprint(value)
+}
+
+func paramIn(paramType fuego.ParamType) func(*fuego.OpenAPIParam) {
+	return func(param *fuego.OpenAPIParam) {
+		param.Type = paramType
+	}
+}
+
+func paramGoType(goType string) func(*fuego.OpenAPIParam) {
+	return func(param *fuego.OpenAPIParam) {
+		param.GoType = goType
+	}
+}
+
+func isInt(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUint(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uint64
+}
+
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
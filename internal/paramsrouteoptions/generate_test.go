@@ -1,47 +1,97 @@
 package paramsrouteoptions
 
 import (
+	"math"
+	"net/netip"
 	"reflect"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-fuego/fuego"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/crunk1/xfuego/internal/field"
 	"github.com/crunk1/xfuego/internal/types"
 )
 
+type EmbeddedParams struct {
+	Y int `query:"y"`
+}
+
+type EmbeddedHeaders struct {
+	Z string `header:"z"`
+}
+
+type duplicateParams struct {
+	Y int `query:"y"`
+	EmbeddedParams
+}
+
+type invalidParams struct {
+	A int    `query:",,default=x"`
+	B uint64 `query:",,default=18446744073709551615"`
+	C string `query:",,min=1"`
+	D int    `path:"d"`
+}
+
+type invalidRouteParams struct {
+	B uint64 `query:",,default=18446744073709551615"`
+	D int    `path:"d"`
+}
+
 func TestGenerate(t *testing.T) {
 	tests := []struct {
 		name        string
-		genFn       func() []func(*fuego.BaseRoute)
+		genFn       func(basePath string, path string) ([]func(*fuego.BaseRoute), error)
+		path        string
 		wantOptsLen int
-		wantPanic   bool
+		wantErr     string
 	}{
-		{"None", Generate[types.None], 0, false},
-		{"empty struct", Generate[struct{}], 0, false},
+		{"None", Generate[types.None], "/", 0, ""},
+		{"empty struct", Generate[struct{}], "/", 0, ""},
 		{"struct with query", Generate[struct {
 			X int `query:""`
-		}], 1, false},
+		}], "/", 1, ""},
 		{"struct with nonparam field", Generate[struct {
 			X int `query:""`
 			Y int `json:"y"`
-		}], 1, false},
-		{"panic on non-struct (int)", Generate[int], 0, true},
-		{"panic on non-struct (*struct)", Generate[*struct{}], 0, true},
+		}], "/", 1, ""},
+		{"struct with embedded params", Generate[struct {
+			X int `query:""`
+			EmbeddedParams
+			*EmbeddedHeaders
+		}], "/", 3, ""},
+		{"struct with path params", Generate[struct {
+			ID   int    `path:"id"`
+			Path string `path:"path"`
+		}], "/foo/{id}/files/{path...}/{$}", 2, ""},
+		{"duplicate embedded param", Generate[duplicateParams], "/", 0, "invalid params struct paramsrouteoptions.duplicateParams: duplicate query param name y: fields=Y,Y"},
+		{"every invalid field", Generate[invalidParams], "/{id}", 0, "invalid params struct paramsrouteoptions.invalidParams: " +
+			`param tag value is invalid: field=A: value is not a valid int: "x"; ` +
+			"param tag value is invalid: field=B: value overflows the OpenAPI integer range: 18446744073709551615; " +
+			"param opts 'min', 'max' and 'multipleOf' are only supported for number params: field=C; " +
+			"path param {d} is not in the route path /{id}: field=D; route path /{id} segment {id} has no path param field"},
+		{"path mismatches and tag value errors", Generate[invalidRouteParams], "/{id}", 0, "invalid params struct paramsrouteoptions.invalidRouteParams: " +
+			"param tag value is invalid: field=B: value overflows the OpenAPI integer range: 18446744073709551615; " +
+			"path param {d} is not in the route path /{id}: field=D; route path /{id} segment {id} has no path param field"},
+		{"non-struct (int)", Generate[int], "/", 0, "invalid params struct int: ReqParamsT type must be a struct"},
+		{"non-struct (*struct)", Generate[*struct{}], "/", 0, "invalid params struct *struct {}: ReqParamsT type must be a struct"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			a := assert.New(t)
-			if tt.wantPanic {
-				a.Panics(func() { tt.genFn() })
+			gotOpts, err := tt.genFn("", tt.path)
+			if tt.wantErr != "" {
+				a.EqualError(err, tt.wantErr)
+				a.IsType(&field.StructError{}, err)
+				a.Nil(gotOpts)
 				return
 			}
-			gotOpts := tt.genFn()
-			if len(gotOpts) != tt.wantOptsLen {
-				t.Errorf("got %v, want %v", len(gotOpts), tt.wantOptsLen)
-			}
+			a.NoError(err)
+			a.Len(gotOpts, tt.wantOptsLen)
 		})
 	}
 }
@@ -64,68 +114,73 @@ func Test_parsedFieldToRouteOption(t *testing.T) {
 		name      string
 		args      args
 		wantParam *fuego.OpenAPIParam // nil is used to indicate parsedFieldToRouteOption should return nil
-		wantPanic bool
 	}{
 		{
 			"not a param",
 			args{in: field.InNone},
 			nil,
-			false,
 		},
 		{
 			"path - basic int",
 			args{in: field.InPath, goKind: reflect.Int},
 			&fuego.OpenAPIParam{Type: "path", Required: true, GoType: "integer"}, // path params are always required
-			false,
 		},
 		{
 			"path - basic int with default value",
 			args{in: field.InPath, goKind: reflect.Int, defaultValue: 10},
 			&fuego.OpenAPIParam{Type: "path", Required: true, GoType: "integer", Default: 10}, // path params are always required
-			false,
 		},
 		{
 			"path - basic int with example",
 			args{in: field.InPath, goKind: reflect.Int, examples: map[string]any{"123": 456}},
 			&fuego.OpenAPIParam{Type: "path", Required: true, GoType: "integer", Examples: map[string]any{"123": 456}}, // path params are always required
-			false,
 		},
 		{
 			"query - required int",
 			args{in: field.InQuery, goKind: reflect.Int, required: true},
-			&fuego.OpenAPIParam{Type: "query", Required: true, GoType: "integer"},
-			false,
+			&fuego.OpenAPIParam{Type: "query", GoType: "integer"}, // only documented as required, see wantRequired
 		},
 		{
 			"query - optional int",
 			args{in: field.InQuery, goKind: reflect.Int},
 			&fuego.OpenAPIParam{Type: "query", GoType: "integer"},
-			false,
 		},
 		{
 			"query - nullable",
 			args{in: field.InQuery, goKind: reflect.Int, nullable: true, required: true},
-			&fuego.OpenAPIParam{Type: "query", Required: true, GoType: "integer", Nullable: true},
-			false,
+			&fuego.OpenAPIParam{Type: "query", GoType: "integer", Nullable: true},
 		},
 		{
 			"query - optional nullable",
 			args{in: field.InQuery, goKind: reflect.Int, nullable: true},
 			&fuego.OpenAPIParam{Type: "query", GoType: "integer", Nullable: true},
-			false,
+		},
+		{
+			"query - int64 with default value",
+			args{in: field.InQuery, goKind: reflect.Int64, defaultValue: int64(10)},
+			&fuego.OpenAPIParam{Type: "query", GoType: "integer", Default: 10}, // fuego expects int values for integer params
+		},
+		{
+			"query - float64 with example",
+			args{in: field.InQuery, goKind: reflect.Float64, examples: map[string]any{"pi": 3.14}},
+			&fuego.OpenAPIParam{Type: "query", GoType: "number", Examples: map[string]any{"pi": 3.14}},
+		},
+		{
+			"header - uint8",
+			args{in: field.InHeader, goKind: reflect.Uint8, required: true},
+			&fuego.OpenAPIParam{Type: "header", GoType: "integer"},
+		},
+		{
+			"cookie - float32",
+			args{in: field.InCookie, goKind: reflect.Float32},
+			&fuego.OpenAPIParam{Type: "cookie", GoType: "number"},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			a := assert.New(t)
 			args := &tt.args
-			if tt.wantPanic {
-				a.Panics(func() {
-					parsedFieldToRouteOption(args.in, args.goKind, args.required, args.nullable, nil, argsName, argsDesc, args.defaultValue, args.examples)
-				})
-				return
-			}
-			routeOpt := parsedFieldToRouteOption(args.in, args.goKind, args.required, args.nullable, nil, argsName, argsDesc, args.defaultValue, args.examples)
+			routeOpt := parsedFieldToRouteOption(field.Param{In: args.in, GoKind: args.goKind, Required: args.required, Nullable: args.nullable, Name: argsName, Desc: argsDesc, DefaultValue: args.defaultValue, Examples: args.examples})
 			if tt.wantParam == nil {
 				a.Nil(routeOpt)
 				return
@@ -138,6 +193,333 @@ func Test_parsedFieldToRouteOption(t *testing.T) {
 				routeOpt(route)
 			}
 			a.Equal(*tt.wantParam, route.Params[argsName])
+			// Required params are documented as required, but only checked by xfuego.
+			wantRequired := args.required || args.in == field.InPath
+			a.Equal(wantRequired, route.Operation.Parameters.GetByInAndName(args.in.String(), argsName).Required)
 		})
 	}
 }
+
+func Test_parsedFieldToRouteOption_schemaFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         field.In
+		goKind     reflect.Kind
+		wantType   string
+		wantFormat string
+		wantMin    *float64
+		wantMax    *float64
+	}{
+		{"query int", field.InQuery, reflect.Int, "integer", "int64", nil, nil},
+		{"query int16", field.InQuery, reflect.Int16, "integer", "int32", nil, nil},
+		{"path uint32", field.InPath, reflect.Uint32, "integer", "int64", lo.ToPtr(0.0), lo.ToPtr(float64(math.MaxUint32))},
+		{"query uint64", field.InQuery, reflect.Uint64, "integer", "", lo.ToPtr(0.0), nil},
+		{"header uint", field.InHeader, reflect.Uint, "integer", "", lo.ToPtr(0.0), nil},
+		{"header float32", field.InHeader, reflect.Float32, "number", "float", nil, nil},
+		{"query float64", field.InQuery, reflect.Float64, "number", "double", nil, nil},
+		{"query string", field.InQuery, reflect.String, "string", "", nil, nil},
+		{"cookie bool", field.InCookie, reflect.Bool, "boolean", "", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			routeOpt := parsedFieldToRouteOption(field.Param{In: tt.in, GoKind: tt.goKind, Name: "x"})
+			route := &fuego.BaseRoute{Operation: &openapi3.Operation{}}
+			routeOpt(route)
+			a.Len(route.Operation.Parameters, 1)
+			schema := route.Operation.Parameters[0].Value.Schema.Value
+			a.True(schema.Type.Is(tt.wantType))
+			a.Equal(tt.wantFormat, schema.Format)
+			a.Equal(tt.wantMin, schema.Min)
+			a.Equal(tt.wantMax, schema.Max)
+		})
+	}
+}
+
+func Test_parsedFieldToRouteOption_slice(t *testing.T) {
+	tests := []struct {
+		name          string
+		param         field.Param
+		wantStyle     string
+		wantExplode   bool
+		wantItemsType string
+		wantDefault   any
+	}{
+		{"query []int", field.Param{In: field.InQuery, GoKind: reflect.Int, Slice: true, Style: "form", Explode: lo.ToPtr(true), DefaultValue: []any{int64(1), int64(2)}}, "form", true, "integer", []any{1, 2}},
+		{"query pipeDelimited []int", field.Param{In: field.InQuery, GoKind: reflect.Int, Slice: true, Style: "pipeDelimited", Explode: lo.ToPtr(false)}, "pipeDelimited", false, "integer", nil},
+		{"header []string", field.Param{In: field.InHeader, GoKind: reflect.String, Slice: true, Style: "simple", Explode: lo.ToPtr(false), Required: true}, "simple", false, "string", nil},
+		{"cookie []float64", field.Param{In: field.InCookie, GoKind: reflect.Float64, Slice: true, Style: "form", Explode: lo.ToPtr(false)}, "form", false, "number", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			tt.param.Name = "x"
+			routeOpt := parsedFieldToRouteOption(tt.param)
+			route := &fuego.BaseRoute{Operation: &openapi3.Operation{}}
+			routeOpt(route)
+			a.Len(route.Operation.Parameters, 1)
+			param := route.Operation.Parameters[0].Value
+			a.Equal(tt.wantStyle, param.Style)
+			a.Equal(tt.wantExplode, *param.Explode)
+			a.Equal(tt.param.Required, param.Required)
+			a.True(param.Schema.Value.Type.Is("array"))
+			a.True(param.Schema.Value.Items.Value.Type.Is(tt.wantItemsType))
+			a.Equal(tt.wantDefault, param.Schema.Value.Default)
+		})
+	}
+}
+
+type uuidParam string
+
+func (*uuidParam) UnmarshalText([]byte) error { return nil }
+
+func (*uuidParam) ParamSchema() *openapi3.Schema { return openapi3.NewUUIDSchema() }
+
+func Test_parsedFieldToRouteOption_textUnmarshaler(t *testing.T) {
+	addrT := reflect.TypeOf(netip.Addr{})
+	uuidT := reflect.TypeOf(uuidParam(""))
+	tests := []struct {
+		name        string
+		param       field.Param
+		wantSchema  *openapi3.Schema // for slices, the items schema
+		wantDefault any
+	}{
+		{
+			"text type with default (TextMarshaler)",
+			field.Param{In: field.InQuery, GoType: addrT, GoKind: reflect.Struct, Text: true, DefaultValue: netip.MustParseAddr("::1")},
+			openapi3.NewStringSchema().WithDefault("::1"),
+			"::1",
+		},
+		{
+			"time.Time",
+			field.Param{In: field.InQuery, GoType: reflect.TypeOf(time.Time{}), GoKind: reflect.Struct, Text: true, DefaultValue: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+			openapi3.NewDateTimeSchema().WithDefault("2025-01-01T00:00:00Z"),
+			"2025-01-01T00:00:00Z",
+		},
+		{
+			"time.Time with layout",
+			field.Param{In: field.InQuery, GoType: reflect.TypeOf(time.Time{}), GoKind: reflect.Struct, Text: true, Layout: "2006-01-02 15:04", DefaultValue: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+			&openapi3.Schema{Type: &openapi3.Types{"string"}, Description: "Go time layout: 2006-01-02 15:04", Default: "2025-01-01 00:00"},
+			"2025-01-01 00:00",
+		},
+		{
+			"Date",
+			field.Param{In: field.InQuery, GoType: reflect.TypeOf(types.Date{}), GoKind: reflect.Struct, Text: true, DefaultValue: types.Date{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}},
+			openapi3.NewStringSchema().WithFormat("date").WithDefault("2025-01-01"),
+			"2025-01-01",
+		},
+		{
+			"time.Duration",
+			field.Param{In: field.InHeader, GoType: reflect.TypeOf(time.Duration(0)), GoKind: reflect.Int64, Text: true, DefaultValue: 90 * time.Second},
+			openapi3.NewStringSchema().WithPattern(types.DurationPattern).WithDefault("1m30s"),
+			"1m30s",
+		},
+		{
+			"schema provider",
+			field.Param{In: field.InHeader, GoType: uuidT, GoKind: reflect.String, Text: true, Nullable: true},
+			openapi3.NewUUIDSchema().WithNullable(),
+			nil,
+		},
+		{
+			"slice of schema providers",
+			field.Param{In: field.InQuery, GoType: uuidT, GoKind: reflect.String, Text: true, Slice: true, Style: "form", Explode: lo.ToPtr(true)},
+			openapi3.NewUUIDSchema(),
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			tt.param.Name = "x"
+			routeOpt := parsedFieldToRouteOption(tt.param)
+			route := &fuego.BaseRoute{Operation: &openapi3.Operation{}}
+			routeOpt(route)
+			a.Len(route.Operation.Parameters, 1)
+			schema := route.Operation.Parameters[0].Value.Schema.Value
+			a.Equal(tt.wantDefault, route.Params["x"].Default)
+			if tt.param.Slice {
+				a.True(schema.Type.Is("array"))
+				schema = schema.Items.Value
+			}
+			a.Equal(tt.wantSchema, schema)
+		})
+	}
+}
+
+func Test_parsedFieldToRouteOption_constraints(t *testing.T) {
+	tests := []struct {
+		name       string
+		param      field.Param
+		wantSchema *openapi3.Schema // for slices, the items schema
+	}{
+		{
+			"int min max",
+			field.Param{In: field.InQuery, GoKind: reflect.Int, Min: lo.ToPtr(1.0), Max: lo.ToPtr(100.0)},
+			openapi3.NewIntegerSchema().WithFormat("int64").WithMin(1).WithMax(100),
+		},
+		{
+			"uint multipleOf keeps min 0 and max 255",
+			field.Param{In: field.InHeader, GoKind: reflect.Uint8, MultipleOf: lo.ToPtr(2.0)},
+			&openapi3.Schema{Type: &openapi3.Types{"integer"}, Format: "int32", Min: lo.ToPtr(0.0), Max: lo.ToPtr(255.0), MultipleOf: lo.ToPtr(2.0)},
+		},
+		{
+			"uint max overrides max 255",
+			field.Param{In: field.InHeader, GoKind: reflect.Uint8, Max: lo.ToPtr(10.0)},
+			&openapi3.Schema{Type: &openapi3.Types{"integer"}, Format: "int32", Min: lo.ToPtr(0.0), Max: lo.ToPtr(10.0)},
+		},
+		{
+			"string lengths and pattern",
+			field.Param{In: field.InQuery, GoKind: reflect.String, MinLength: lo.ToPtr[uint64](1), MaxLength: lo.ToPtr[uint64](3), Pattern: regexp.MustCompile("^[a-z]+$")},
+			openapi3.NewStringSchema().WithMinLength(1).WithMaxLength(3).WithPattern("^[a-z]+$"),
+		},
+		{
+			"slice enum",
+			field.Param{In: field.InQuery, GoKind: reflect.Int8, Slice: true, Style: "form", Explode: lo.ToPtr(true), Enum: []any{int8(1), int8(2)}},
+			openapi3.NewIntegerSchema().WithFormat("int32").WithEnum(1, 2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			tt.param.Name = "x"
+			routeOpt := parsedFieldToRouteOption(tt.param)
+			route := &fuego.BaseRoute{Operation: &openapi3.Operation{}}
+			routeOpt(route)
+			a.Len(route.Operation.Parameters, 1)
+			schema := route.Operation.Parameters[0].Value.Schema.Value
+			if tt.param.Slice {
+				schema = schema.Items.Value
+			}
+			a.Equal(tt.wantSchema, schema)
+		})
+	}
+}
+
+func TestGenerate_objects(t *testing.T) {
+	type FilterParams struct {
+		Status string `query:"status,Status filter"`
+		Owner  *int   `query:"owner"`
+	}
+	type Params struct {
+		Filter FilterParams `query:"filter,Filters"`
+		Sort   FilterParams `query:"sort,,separator=."`
+	}
+	a := assert.New(t)
+	route := &fuego.BaseRoute{Operation: openapi3.NewOperation()}
+	opts, err := Generate[Params]("", "/")
+	a.NoError(err)
+	for _, opt := range opts {
+		opt(route)
+	}
+	a.Len(route.Operation.Parameters, 3)
+
+	filter := route.Operation.Parameters.GetByInAndName("query", "filter")
+	a.Equal("deepObject", filter.Style)
+	a.True(*filter.Explode)
+	a.True(filter.Required)
+	a.Equal("Filters", filter.Description)
+	a.True(filter.Schema.Value.Type.Is("object"))
+	a.Equal([]string{"status"}, filter.Schema.Value.Required)
+	a.Equal(&openapi3.Schema{Type: &openapi3.Types{"string"}, Description: "Status filter"}, filter.Schema.Value.Properties["status"].Value)
+	a.Equal(openapi3.NewIntegerSchema().WithFormat("int64"), filter.Schema.Value.Properties["owner"].Value)
+	a.False(route.Params["filter"].Required) // nested params are checked on their own
+
+	a.NotNil(route.Operation.Parameters.GetByInAndName("query", "sort.status"))
+	a.True(route.Operation.Parameters.GetByInAndName("query", "sort.status").Required)
+	a.NotNil(route.Operation.Parameters.GetByInAndName("query", "sort.owner"))
+}
+
+func TestGenerate_form(t *testing.T) {
+	type Params struct {
+		User     string   `form:"user,User name,minLength=2,alias=login"`
+		Remember bool     `form:"remember,,default=true"`
+		Scopes   []string `form:"scope,,style=pipeDelimited,explode=false"`
+		Limit    int      `query:"limit"`
+	}
+	a := assert.New(t)
+	route := &fuego.BaseRoute{Operation: openapi3.NewOperation()}
+	opts, err := Generate[Params]("", "/")
+	a.NoError(err)
+	for _, opt := range opts {
+		opt(route)
+	}
+	a.Len(route.Operation.Parameters, 1) // form params are not params
+	a.NotNil(route.Operation.Parameters.GetByInAndName("query", "limit"))
+
+	body := route.Operation.RequestBody.Value
+	a.True(body.Required)
+	mediaType := body.Content.Get("application/x-www-form-urlencoded")
+	schema := mediaType.Schema.Value
+	a.True(schema.Type.Is("object"))
+	a.Equal([]string{"user", "scope"}, schema.Required)
+	a.Equal(&openapi3.Schema{Type: &openapi3.Types{"string"}, Description: "User name", MinLength: 2}, schema.Properties["user"].Value)
+	a.Equal(&openapi3.Schema{Type: &openapi3.Types{"string"}, Description: "Deprecated alias of user.", MinLength: 2, Deprecated: true}, schema.Properties["login"].Value)
+	a.Equal(true, schema.Properties["remember"].Value.Default)
+	a.True(schema.Properties["scope"].Value.Type.Is("array"))
+	a.Equal(map[string]*openapi3.Encoding{"scope": {Style: "pipeDelimited", Explode: lo.ToPtr(false)}}, mediaType.Encoding)
+}
+
+func Test_parsedFieldToRouteOption_map(t *testing.T) {
+	a := assert.New(t)
+	p := field.Param{In: field.InHeader, Name: "X-Meta-", Desc: "Metadata", GoKind: reflect.Uint8, Map: true, Max: lo.ToPtr(10.0)}
+	route := &fuego.BaseRoute{Operation: openapi3.NewOperation()}
+	parsedFieldToRouteOption(p)(route)
+	a.Len(route.Operation.Parameters, 1)
+	param := route.Operation.Parameters[0].Value
+	a.Equal("X-Meta-", param.Name)
+	a.Equal("header", param.In)
+	a.Equal("Metadata", param.Description)
+	a.False(param.Required)
+	a.True(param.Schema.Value.Type.Is("object"))
+	a.Equal(`All the header params prefixed with "X-Meta-", keyed by their unprefixed names.`, param.Schema.Value.Description)
+	a.Equal(openapi3.NewIntegerSchema().WithFormat("int32").WithMin(0).WithMax(10), param.Schema.Value.AdditionalProperties.Schema.Value)
+}
+
+func TestGenerate_groupParams(t *testing.T) {
+	type Params struct {
+		Tenant string `header:"X-Tenant"`
+		Org    string `path:"org"`
+	}
+	a := assert.New(t)
+	opts, err := Generate[Params]("/orgs/{org}", "/users")
+	a.NoError(err)
+	route := &fuego.BaseRoute{Operation: openapi3.NewOperation()}
+	groupOpts := []func(*fuego.BaseRoute){fuego.OptionHeader("X-Tenant", "Tenant from the group"), fuego.OptionQuery("q", "")}
+	for _, opt := range append(groupOpts, opts...) {
+		opt(route)
+	}
+	a.Len(route.Operation.Parameters, 3) // the group's X-Tenant header is replaced
+	a.Equal("", route.Operation.Parameters.GetByInAndName("header", "X-Tenant").Description)
+	a.True(route.Operation.Parameters.GetByInAndName("header", "X-Tenant").Required)
+	a.NotNil(route.Operation.Parameters.GetByInAndName("query", "q"))
+
+	_, err = Generate[struct{}]("/orgs/{org}", "/users") // group path wildcards are optional
+	a.NoError(err)
+}
+
+func Test_parsedFieldToRouteOption_aliases(t *testing.T) {
+	a := assert.New(t)
+	type Params struct {
+		Limit int    `query:"limit,Page size,alias=page_size"`
+		Sort  string `header:"X-Sort,,default=asc,deprecated"`
+	}
+	route := &fuego.BaseRoute{Operation: openapi3.NewOperation()}
+	for _, p := range field.ParseStruct(reflect.TypeOf(Params{})) {
+		parsedFieldToRouteOption(p)(route)
+	}
+
+	limit := route.Operation.Parameters.GetByInAndName("query", "limit")
+	a.True(limit.Required)
+	a.False(limit.Deprecated)
+	a.Equal("Page size", limit.Description)
+	a.False(route.Params["limit"].Required) // only checked by xfuego
+
+	pageSize := route.Operation.Parameters.GetByInAndName("query", "page_size")
+	a.False(pageSize.Required)
+	a.True(pageSize.Deprecated)
+	a.Equal("Deprecated alias of limit.", pageSize.Description)
+	a.Equal(limit.Schema.Value.Type, pageSize.Schema.Value.Type)
+
+	sort := route.Operation.Parameters.GetByInAndName("header", "X-Sort")
+	a.True(sort.Deprecated)
+}
@@ -0,0 +1,34 @@
+package paramsrouteoptions
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/crunk1/xfuego/internal/field"
+)
+
+// generated maps the params struct types to their params generated by xfuego-gen, see Register.
+var generated sync.Map
+
+// Register registers the params of ReqParamsT generated by xfuego-gen, which Generate documents instead of parsing
+// ReqParamsT with reflection. They are registered along with the generated populate function of ReqParamsT, so
+// paramspopulator.CheckGenerated tells whether they are up to date.
+func Register[ReqParamsT any](params []field.Param) {
+	generated.Store(reflect.TypeFor[ReqParamsT](), params)
+}
+
+// generatedParams returns the registered params of t, if any. The params without a description are described by the
+// registered doc comment of their field, as when parsing t.
+func generatedParams(t reflect.Type) ([]field.Param, bool) {
+	v, ok := generated.Load(t)
+	if !ok {
+		return nil, false
+	}
+	params := append([]field.Param(nil), v.([]field.Param)...)
+	for i, p := range params {
+		if p.Desc == "" {
+			params[i].Desc = field.FieldDoc(t, p.Field.Name)
+		}
+	}
+	return params, true
+}
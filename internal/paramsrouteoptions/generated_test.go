@@ -0,0 +1,42 @@
+package paramsrouteoptions
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-fuego/fuego"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/crunk1/xfuego/internal/field"
+)
+
+func TestGenerate_generated(t *testing.T) {
+	type Params struct {
+		Limit int    `query:"limit,,default=20"`
+		Sort  string `query:"sort"`
+		ID    int    `path:"id"`
+	}
+	a := assert.New(t)
+	params := field.ParseStruct(reflect.TypeFor[Params]())
+	params[0].Desc = "Generated"
+	Register[Params](params)
+	defer generated.Delete(reflect.TypeFor[Params]())
+	field.RegisterDocs(reflect.TypeFor[Params](), map[string]string{"Limit": "Field doc", "Sort": "Sort order"})
+
+	route := &fuego.BaseRoute{Operation: openapi3.NewOperation()}
+	opts, err := Generate[Params]("", "/items/{id}")
+	a.NoError(err)
+	for _, opt := range opts {
+		opt(route)
+	}
+	a.Len(route.Operation.Parameters, 3)
+	a.Equal("Generated", route.Operation.Parameters.GetByInAndName("query", "limit").Description)
+	a.Equal(20, route.Operation.Parameters.GetByInAndName("query", "limit").Schema.Value.Default)
+	a.Equal("Sort order", route.Operation.Parameters.GetByInAndName("query", "sort").Description)
+	a.Equal("", params[1].Desc, "the registered params are not modified")
+
+	// The generated params are checked like the parsed ones
+	_, err = Generate[Params]("", "/items")
+	a.EqualError(err, "invalid params struct paramsrouteoptions.Params: path param {id} is not in the route path /items: field=ID")
+}
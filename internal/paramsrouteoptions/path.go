@@ -0,0 +1,48 @@
+package paramsrouteoptions
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/crunk1/xfuego/internal/field"
+)
+
+// pathWildcardRegexp matches the wildcards of a net/http route pattern, e.g. `{id}` or `{path...}`.
+var pathWildcardRegexp = regexp.MustCompile(`\{([^}]*)\}`)
+
+// checkPath returns the mismatches between the path params and the wildcards of the route path, e.g. a `path:"id"`
+// field for a `/foo/{id}` route. Every path param must match a wildcard of the full route path, and every wildcard of
+// the route's own path must have a path param. The wildcards of the basePath, e.g. a fuego.Group path, belong to the
+// group: routes may or may not declare them.
+func checkPath(basePath string, path string, params []field.Param) (mismatches []string) {
+	fullPath := basePath + path
+	baseWildcards, wildcards := pathWildcards(basePath), pathWildcards(path)
+
+	var names []string
+	for _, p := range params {
+		if p.In != field.InPath {
+			continue
+		}
+		names = append(names, p.Name)
+		if !slices.Contains(wildcards, p.Name) && !slices.Contains(baseWildcards, p.Name) {
+			mismatches = append(mismatches, "path param {"+p.Name+"} is not in the route path "+fullPath+": field="+p.Field.Name)
+		}
+	}
+	for _, wildcard := range wildcards {
+		if !slices.Contains(names, wildcard) {
+			mismatches = append(mismatches, "route path "+fullPath+" segment {"+wildcard+"} has no path param field")
+		}
+	}
+	return mismatches
+}
+
+// pathWildcards returns the names of the wildcards of a route path.
+func pathWildcards(path string) (names []string) {
+	for _, match := range pathWildcardRegexp.FindAllStringSubmatch(path, -1) {
+		if name := strings.TrimSuffix(match[1], "..."); name != "$" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
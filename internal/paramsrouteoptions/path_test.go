@@ -0,0 +1,50 @@
+package paramsrouteoptions
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/crunk1/xfuego/internal/field"
+)
+
+func Test_checkPath(t *testing.T) {
+	type IDParams struct {
+		ID int    `path:"id"`
+		Q  string `query:"id"`
+	}
+	type TenantParams struct {
+		Tenant string `path:"tenant"`
+	}
+	type EmbeddedParams struct {
+		TenantParams
+		Path string `path:"path"`
+	}
+	tests := []struct {
+		name           string
+		paramsT        reflect.Type
+		basePath       string
+		path           string
+		wantMismatches []string
+	}{
+		{"match", reflect.TypeOf(IDParams{}), "", "/foo/{id}", nil},
+		{"match with wildcard", reflect.TypeOf(EmbeddedParams{}), "", "/tenants/{tenant}/files/{path...}", nil},
+		{"match with group prefix", reflect.TypeOf(EmbeddedParams{}), "/tenants/{tenant}", "/files/{path...}", nil},
+		{"undeclared group prefix wildcard", reflect.TypeOf(struct{}{}), "/tenants/{tenant}", "/files", nil},
+		{"trailing slash anchor", reflect.TypeOf(IDParams{}), "", "/foo/{id}/{$}", nil},
+		{"no path params", reflect.TypeOf(struct{}{}), "", "/foo", nil},
+		{"field without segment", reflect.TypeOf(IDParams{}), "", "/foo/{ID}", []string{
+			"path param {id} is not in the route path /foo/{ID}: field=ID",
+			"route path /foo/{ID} segment {ID} has no path param field",
+		}},
+		{"segment without field", reflect.TypeOf(struct{}{}), "", "/foo/{id}", []string{
+			"route path /foo/{id} segment {id} has no path param field",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantMismatches, checkPath(tt.basePath, tt.path, field.ParseStruct(tt.paramsT)))
+		})
+	}
+}
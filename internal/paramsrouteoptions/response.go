@@ -0,0 +1,159 @@
+package paramsrouteoptions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-fuego/fuego"
+
+	"github.com/crunk1/xfuego/internal/field"
+	"github.com/crunk1/xfuego/internal/types"
+)
+
+// GenerateResponse returns the route option documenting the default status code response of a route whose controller
+// returns BodyT with HeadersT response headers and cookies: its BodyT content, and its HeadersT headers. OpenAPI has no
+// response cookies, so cookie fields are documented as a Set-Cookie header.
+// It returns a *field.StructError listing every invalid field of HeadersT.
+func GenerateResponse[HeadersT any, BodyT any]() (func(*fuego.BaseRoute), error) {
+	headers := openapi3.Headers{}
+	if !types.IsNoneType[HeadersT]() {
+		t := reflect.TypeOf((*HeadersT)(nil)).Elem()
+		if t.Kind() != reflect.Struct {
+			return nil, &field.StructError{Type: t, Errors: []string{"HeadersT type must be a struct"}}
+		}
+
+		var errs []string
+		params, err := field.TryParseStruct(t)
+		var structErr *field.StructError
+		if errors.As(err, &structErr) {
+			errs = append(errs, structErr.Errors...)
+		}
+		var cookies []string
+		for _, p := range params {
+			if errMsg := checkResponseParam(p); errMsg != "" {
+				errs = append(errs, errMsg)
+				continue
+			}
+			if p.In == field.InCookie {
+				cookies = append(cookies, p.Name)
+				continue
+			}
+			header, errMsg := responseHeader(p)
+			if errMsg != "" {
+				errs = append(errs, errMsg)
+				continue
+			}
+			headers[p.Name] = &openapi3.HeaderRef{Value: header}
+		}
+		if len(errs) > 0 {
+			return nil, &field.StructError{Type: t, Errors: errs}
+		}
+		if len(cookies) > 0 {
+			headers["Set-Cookie"] = &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+				Description: "Sets the cookies: " + strings.Join(cookies, ", ") + ".",
+				Schema:      openapi3.NewStringSchema().NewRef(),
+			}}}
+		}
+	}
+
+	return func(r *fuego.BaseRoute) {
+		statusCode := r.DefaultStatusCode
+		if statusCode == 0 {
+			statusCode = http.StatusOK
+		}
+		response := r.Operation.Responses.Value(strconv.Itoa(statusCode))
+		if response == nil {
+			r.Operation.AddResponse(statusCode, openapi3.NewResponse().WithDescription(http.StatusText(statusCode)))
+			response = r.Operation.Responses.Value(strconv.Itoa(statusCode))
+		}
+		if response.Value.Content == nil && r.OpenAPI != nil {
+			bodySchema := fuego.SchemaTagFromType(r.OpenAPI, *new(BodyT))
+			response.Value.WithContent(openapi3.NewContentWithSchemaRef(&bodySchema.SchemaRef, []string{"application/json", "application/xml"}))
+		}
+		if len(headers) > 0 && response.Value.Headers == nil {
+			response.Value.Headers = openapi3.Headers{}
+		}
+		for name, header := range headers {
+			response.Value.Headers[name] = header
+		}
+	}, nil
+}
+
+// checkResponseParam returns why a param cannot be a response header or cookie, or "".
+func checkResponseParam(p field.Param) string {
+	if p.In != field.InHeader && p.In != field.InCookie {
+		return "response headers struct fields must be header or cookie fields: field=" + p.Field.Name
+	} else if p.Map {
+		return "map fields are not supported for response headers: field=" + p.Field.Name
+	} else if p.DefaultValue != nil {
+		return "param opt 'default' is not supported for response headers: field=" + p.Field.Name
+	} else if p.Aliases != nil {
+		return "param opt 'alias' is not supported for response headers: field=" + p.Field.Name
+	}
+	return ""
+}
+
+// responseHeader returns the OpenAPI response header of a header param, i.e. its parameter without name and location,
+// or the message of any panic building it.
+func responseHeader(p field.Param) (*openapi3.Header, string) {
+	opt, errMsg := tryRouteOption(p.Field.Name, func() func(*fuego.BaseRoute) { return parsedFieldToRouteOption(p) })
+	if errMsg != "" {
+		return nil, errMsg
+	}
+	route := &fuego.BaseRoute{Operation: openapi3.NewOperation()}
+	opt(route)
+	header := &openapi3.Header{Parameter: *route.Operation.Parameters[0].Value}
+	header.Name, header.In = "", ""
+	return header, ""
+}
+
+// StatusResponse is one of the declared responses of a route with several outcomes.
+type StatusResponse struct {
+	StatusCode int
+	Body       any // A zero value of the response body type, for its schema; nil for no content.
+}
+
+// GenerateStatusResponses returns the route option documenting the declared responses of a route whose controller
+// returns one of several outcomes, as declared by the result type t. The first response's status code is the route's
+// default status code. It returns a *field.StructError listing every invalid or duplicate status code.
+func GenerateStatusResponses(t reflect.Type, responses []StatusResponse) (func(*fuego.BaseRoute), error) {
+	var errs []string
+	variants := map[int]int{} // status code -> variant
+	for i, resp := range responses {
+		if resp.StatusCode < 100 || resp.StatusCode > 599 {
+			errs = append(errs, fmt.Sprintf("invalid status code %d: variant=%d", resp.StatusCode, i+1))
+		} else if j, ok := variants[resp.StatusCode]; ok {
+			errs = append(errs, fmt.Sprintf("duplicate status code %d: variants=%d,%d", resp.StatusCode, j, i+1))
+		} else {
+			variants[resp.StatusCode] = i + 1
+		}
+	}
+	if len(errs) > 0 {
+		return nil, &field.StructError{Type: t, Errors: errs}
+	}
+
+	return func(r *fuego.BaseRoute) {
+		r.DefaultStatusCode = responses[0].StatusCode
+		for _, resp := range responses {
+			response := r.Operation.Responses.Value(strconv.Itoa(resp.StatusCode))
+			if response == nil {
+				r.Operation.AddResponse(resp.StatusCode, openapi3.NewResponse().WithDescription(http.StatusText(resp.StatusCode)))
+				response = r.Operation.Responses.Value(strconv.Itoa(resp.StatusCode))
+			}
+			if response.Value.Content != nil {
+				continue
+			}
+			// An empty content keeps fuego from documenting the result type as the default status code's content.
+			response.Value.Content = openapi3.Content{}
+			if resp.Body != nil && r.OpenAPI != nil {
+				bodySchema := fuego.SchemaTagFromType(r.OpenAPI, resp.Body)
+				response.Value.WithContent(openapi3.NewContentWithSchemaRef(&bodySchema.SchemaRef, []string{"application/json", "application/xml"}))
+			}
+		}
+	}, nil
+}
@@ -0,0 +1,114 @@
+package paramsrouteoptions
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-fuego/fuego"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/crunk1/xfuego/internal/types"
+)
+
+func TestGenerateResponse(t *testing.T) {
+	type Headers struct {
+		RateLimit int            `header:"X-Rate-Limit,Requests left"`
+		Retry     *time.Duration `header:"Retry-After"`
+		Tags      []string       `header:"X-Tags"`
+		Session   string         `cookie:"session"`
+		Theme     *string        `cookie:"theme"`
+	}
+	type Body struct {
+		Name string
+	}
+	a := assert.New(t)
+	opt, err := GenerateResponse[Headers, Body]()
+	a.NoError(err)
+	route := &fuego.BaseRoute{Operation: openapi3.NewOperation(), OpenAPI: fuego.NewOpenAPI(), DefaultStatusCode: 201}
+	opt(route)
+
+	response := route.Operation.Responses.Value("201").Value
+	a.Equal("Created", *response.Description)
+	a.Equal("#/components/schemas/Body", response.Content.Get("application/json").Schema.Ref)
+	a.Len(response.Headers, 4)
+	rateLimit := response.Headers["X-Rate-Limit"].Value
+	a.Equal("", rateLimit.Name)
+	a.Equal("", rateLimit.In)
+	a.Equal("Requests left", rateLimit.Description)
+	a.True(rateLimit.Required)
+	a.Equal(openapi3.NewIntegerSchema().WithFormat("int64"), rateLimit.Schema.Value)
+	a.False(response.Headers["Retry-After"].Value.Required)
+	a.True(response.Headers["X-Tags"].Value.Schema.Value.Type.Is("array"))
+	a.Equal("Sets the cookies: session, theme.", response.Headers["Set-Cookie"].Value.Description)
+
+	// An existing response of the default status code is completed.
+	route = &fuego.BaseRoute{Operation: openapi3.NewOperation(), OpenAPI: fuego.NewOpenAPI()}
+	route.Operation.AddResponse(200, openapi3.NewResponse().WithDescription("The items").WithContent(openapi3.NewContentWithJSONSchema(openapi3.NewStringSchema())))
+	opt(route)
+	response = route.Operation.Responses.Value("200").Value
+	a.Equal("The items", *response.Description)
+	a.True(response.Content.Get("application/json").Schema.Value.Type.Is("string"))
+	a.Len(response.Headers, 4)
+
+	opt, err = GenerateResponse[types.None, Body]()
+	a.NoError(err)
+	route = &fuego.BaseRoute{Operation: openapi3.NewOperation(), OpenAPI: fuego.NewOpenAPI()}
+	opt(route)
+	a.Empty(route.Operation.Responses.Value("200").Value.Headers)
+}
+
+func TestGenerateResponse_errors(t *testing.T) {
+	type Headers struct {
+		Query   int               `query:"q"`
+		Meta    map[string]string `header:"X-Meta-"`
+		Default int               `header:"X-Default,,default=1"`
+		Example int               `header:"X-Example,,example=ex=x"`
+	}
+	a := assert.New(t)
+	_, err := GenerateResponse[Headers, string]()
+	a.EqualError(err, "invalid params struct paramsrouteoptions.Headers: "+
+		"param tag value is invalid: field=Example: value is not a valid int: \"x\"; "+
+		"response headers struct fields must be header or cookie fields: field=Query; "+
+		"map fields are not supported for response headers: field=Meta; "+
+		"param opt 'default' is not supported for response headers: field=Default")
+
+	_, err = GenerateResponse[int, string]()
+	a.EqualError(err, "invalid params struct int: HeadersT type must be a struct")
+}
+
+func TestGenerateStatusResponses(t *testing.T) {
+	type User struct {
+		Name string
+	}
+	type Result struct{}
+	a := assert.New(t)
+	opt, err := GenerateStatusResponses(reflect.TypeFor[Result](), []StatusResponse{
+		{StatusCode: 200, Body: User{}},
+		{StatusCode: 201, Body: User{}},
+		{StatusCode: 204},
+	})
+	a.NoError(err)
+	route := &fuego.BaseRoute{Operation: openapi3.NewOperation(), OpenAPI: fuego.NewOpenAPI()}
+	route.Operation.AddResponse(201, openapi3.NewResponse().WithDescription("The user was created"))
+	opt(route)
+
+	a.Equal(200, route.DefaultStatusCode)
+	a.Len(route.Operation.Responses.Map(), 4) // and the "default" response of openapi3.NewOperation
+	ok := route.Operation.Responses.Value("200").Value
+	a.Equal("OK", *ok.Description)
+	a.Equal("#/components/schemas/User", ok.Content.Get("application/json").Schema.Ref)
+	created := route.Operation.Responses.Value("201").Value
+	a.Equal("The user was created", *created.Description)
+	a.Equal("#/components/schemas/User", created.Content.Get("application/xml").Schema.Ref)
+	noContent := route.Operation.Responses.Value("204").Value
+	a.Equal("No Content", *noContent.Description)
+	a.Equal(openapi3.Content{}, noContent.Content)
+
+	_, err = GenerateStatusResponses(reflect.TypeFor[Result](), []StatusResponse{
+		{StatusCode: 200}, {StatusCode: 99}, {StatusCode: 200},
+	})
+	a.EqualError(err, "invalid params struct paramsrouteoptions.Result: "+
+		"invalid status code 99: variant=2; duplicate status code 200: variants=1,3")
+}
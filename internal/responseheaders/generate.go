@@ -0,0 +1,146 @@
+// Package responseheaders.Generate generates a function that writes a struct of typed response headers and cookies to
+// the response.
+//
+// The struct is checked at route registration by paramsrouteoptions.GenerateResponse.
+package responseheaders
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/crunk1/xfuego/internal/field"
+	"github.com/crunk1/xfuego/internal/types"
+)
+
+// Generate returns a function that writes the header and cookie fields of a HeadersT struct to the response. Nil
+// optional fields are not written, and nil Nullable fields are written as "null". The function returns an error,
+// without writing any field, if a field value cannot be formatted, i.e. if its MarshalText method fails.
+func Generate[HeadersT any]() func(http.ResponseWriter, *HeadersT) error {
+	// No headers -> no-op
+	if types.IsNoneType[HeadersT]() {
+		return func(http.ResponseWriter, *HeadersT) error { return nil }
+	}
+
+	t := reflect.TypeOf((*HeadersT)(nil)).Elem()
+	params := field.ParseStruct(t)
+
+	return func(w http.ResponseWriter, headers *HeadersT) error {
+		// Formatted first, so that no field is written if one fails.
+		values := make([]*string, len(params))
+		for i, p := range params {
+			value, ok, err := fieldValue(p, headers)
+			if err != nil {
+				return err
+			}
+			if ok {
+				values[i] = &value
+			}
+		}
+		for i, p := range params {
+			if values[i] == nil {
+				continue
+			}
+			if p.In == field.InCookie {
+				http.SetCookie(w, &http.Cookie{Name: p.Name, Value: *values[i]})
+			} else {
+				w.Header().Set(p.Name, *values[i])
+			}
+		}
+		return nil
+	}
+}
+
+// fieldValue returns the formatted value of a header or cookie field of a HeadersT struct. ok is false if the field is
+// not written.
+func fieldValue[HeadersT any](p field.Param, headers *HeadersT) (value string, ok bool, err error) {
+	fieldPtr := getFieldPtr(headers, p.Embeddings, p.Field.Offset)
+	if fieldPtr == nil {
+		return "", false, nil
+	}
+	value, ok, err = formatValue(p, reflect.NewAt(p.Field.Type, fieldPtr).Elem())
+	if err != nil {
+		return "", false, fmt.Errorf("response %s %s: %w", p.In, p.Name, err)
+	}
+	return value, ok, nil
+}
+
+// formatValue formats a field value as a header or cookie value, the inverse of the param's StrconvFn. Slice values
+// are comma-separated. ok is false for nil optional fields.
+func formatValue(p field.Param, v reflect.Value) (value string, ok bool, err error) {
+	if v.Kind() == reflect.Pointer && !types.IsNullable(v.Type()) {
+		if v.IsNil() {
+			return "", false, nil
+		}
+		v = v.Elem()
+	}
+	if types.IsNullable(v.Type()) {
+		if v.IsNil() {
+			return "null", true, nil
+		}
+		v = v.Elem()
+	}
+	if !p.Slice {
+		value, err := formatScalar(p, v)
+		return value, err == nil, err
+	}
+	values := make([]string, v.Len())
+	for i := range values {
+		if values[i], err = formatScalar(p, v.Index(i)); err != nil {
+			return "", false, err
+		}
+	}
+	return strings.Join(values, ","), true, nil
+}
+
+// formatScalar formats a single (non-slice) value. It returns the error of a failing MarshalText method.
+func formatScalar(p field.Param, v reflect.Value) (string, error) {
+	if p.Text {
+		value := v.Interface()
+		if t, ok := value.(time.Time); ok && p.Layout != "" {
+			return t.Format(p.Layout), nil
+		} else if d, ok := value.(types.Date); ok && p.Layout != "" {
+			return d.Format(p.Layout), nil
+		} else if marshaler, ok := value.(encoding.TextMarshaler); ok {
+			text, err := marshaler.MarshalText()
+			if err != nil {
+				return "", fmt.Errorf("value cannot be marshaled to text: %w", err)
+			}
+			return string(text), nil
+		} else if stringer, ok := value.(fmt.Stringer); ok {
+			return stringer.String(), nil
+		}
+		return fmt.Sprint(value), nil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+	return v.String(), nil
+}
+
+// getFieldPtr returns a pointer to a field of headers, reached through embeddings, or nil if a pointer embedding is nil.
+func getFieldPtr[T any](headers *T, embeddings []field.Embedding, fieldOffset uintptr) unsafe.Pointer {
+	structPtr := unsafe.Pointer(headers)
+	for _, embedding := range embeddings {
+		structPtr = unsafe.Add(structPtr, embedding.Offset)
+		if embedding.Ptr {
+			structPtr = *(*unsafe.Pointer)(structPtr)
+			if structPtr == nil {
+				return nil
+			}
+		}
+	}
+	return unsafe.Add(structPtr, fieldOffset)
+}
@@ -0,0 +1,102 @@
+package responseheaders
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/crunk1/xfuego/internal/types"
+)
+
+type Cookies struct {
+	Session string `cookie:"session"`
+}
+
+func TestGenerate(t *testing.T) {
+	type Headers struct {
+		RateLimit int                  `header:"X-Rate-Limit"`
+		Ratio     float32              `header:"X-Ratio"`
+		Enabled   bool                 `header:"X-Enabled"`
+		Count     uint8                `header:"X-Count"`
+		Retry     *time.Duration       `header:"Retry-After"`
+		Missing   *string              `header:"X-Missing"`
+		Null      types.Nullable[int]  `header:"X-Null"`
+		NotNull   *types.Nullable[int] `header:"X-Not-Null"`
+		Tags      []string             `header:"X-Tags"`
+		IP        net.IP               `header:"X-IP"`
+		Day       types.Date           `header:"X-Day"`
+		Modified  time.Time            `header:"X-Modified,,layout=2006-01-02 15:04"`
+		Steps     []time.Duration      `header:"X-Steps"`
+		Theme     *string              `cookie:"theme"`
+		*Cookies
+	}
+	a := assert.New(t)
+	write := Generate[Headers]()
+	w := httptest.NewRecorder()
+	a.NoError(write(w, &Headers{
+		RateLimit: -5,
+		Ratio:     0.5,
+		Enabled:   true,
+		Count:     255,
+		Retry:     lo.ToPtr(90 * time.Second),
+		NotNull:   lo.ToPtr(types.Nullable[int](lo.ToPtr(3))),
+		Tags:      []string{"a", "b"},
+		IP:        net.ParseIP("192.168.0.1"),
+		Day:       types.Date{Time: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		Modified:  time.Date(2025, 3, 1, 12, 30, 0, 0, time.UTC),
+		Steps:     []time.Duration{time.Second, 2 * time.Minute},
+		Cookies:   &Cookies{Session: "abc"},
+	}))
+	a.Equal(http.Header{
+		"X-Rate-Limit": {"-5"},
+		"X-Ratio":      {"0.5"},
+		"X-Enabled":    {"true"},
+		"X-Count":      {"255"},
+		"Retry-After":  {"1m30s"},
+		"X-Null":       {"null"},
+		"X-Not-Null":   {"3"},
+		"X-Tags":       {"a,b"},
+		"X-Ip":         {"192.168.0.1"},
+		"X-Day":        {"2025-03-01"},
+		"X-Modified":   {"2025-03-01 12:30"},
+		"X-Steps":      {"1s,2m0s"},
+		"Set-Cookie":   {"session=abc"},
+	}, w.Header())
+
+	// Nil pointer embeddings are skipped.
+	w = httptest.NewRecorder()
+	a.NoError(write(w, &Headers{Theme: lo.ToPtr("dark")}))
+	a.Equal([]string{"theme=dark"}, w.Header().Values("Set-Cookie"))
+
+	w = httptest.NewRecorder()
+	a.NoError(Generate[types.None]()(w, new(types.None)))
+	a.Empty(w.Header())
+}
+
+type failingText struct{}
+
+func (failingText) MarshalText() ([]byte, error) {
+	return nil, errors.New("marshal failed")
+}
+
+func (*failingText) UnmarshalText([]byte) error {
+	return nil
+}
+
+func TestGenerate_marshalTextError(t *testing.T) {
+	type Headers struct {
+		RateLimit int         `header:"X-Rate-Limit"`
+		Failing   failingText `header:"X-Failing"`
+	}
+	a := assert.New(t)
+	w := httptest.NewRecorder()
+	err := Generate[Headers]()(w, &Headers{RateLimit: 5})
+	a.EqualError(err, "response header X-Failing: value cannot be marshaled to text: marshal failed")
+	a.Empty(w.Header(), "no field is written")
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// DateLayout is the layout of Date values: an RFC 3339 full-date.
+const DateLayout = time.DateOnly
+
+// Date is a date-only param value, e.g. "2025-01-31". It is documented as an OpenAPI string with format date.
+type Date struct {
+	time.Time
+}
+
+func (d *Date) UnmarshalText(text []byte) error {
+	t, err := time.Parse(DateLayout, string(text))
+	if err != nil {
+		return err
+	}
+	d.Time = t
+	return nil
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d Date) String() string {
+	return d.Format(DateLayout)
+}
+
+func (Date) ParamSchema() *openapi3.Schema {
+	return openapi3.NewStringSchema().WithFormat("date")
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDate(t *testing.T) {
+	a := assert.New(t)
+	var d Date
+	a.NoError(d.UnmarshalText([]byte("2025-01-31")))
+	a.Equal(time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), d.Time)
+	text, err := d.MarshalText()
+	a.NoError(err)
+	a.Equal("2025-01-31", string(text))
+	a.Error(d.UnmarshalText([]byte("2025-01-31T00:00:00Z")))
+}
+
+func TestDurationPattern(t *testing.T) {
+	a := assert.New(t)
+	pattern := regexp.MustCompile(DurationPattern)
+	for _, valid := range []string{"0", "30s", "1h30m", "-1.5h", "100ms", "2us", "3µs"} {
+		_, err := time.ParseDuration(valid)
+		a.NoError(err, valid)
+		a.True(pattern.MatchString(valid), valid)
+	}
+	for _, invalid := range []string{"", "30", "1d", "abc"} {
+		a.False(pattern.MatchString(invalid), invalid)
+	}
+}
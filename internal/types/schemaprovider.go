@@ -0,0 +1,45 @@
+package types
+
+import (
+	"encoding"
+	"reflect"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ParamSchemaProvider can be implemented by encoding.TextUnmarshaler param types to override their OpenAPI schema,
+// which is a plain string schema by default.
+type ParamSchemaProvider interface {
+	ParamSchema() *openapi3.Schema
+}
+
+// DurationPattern is the OpenAPI pattern of time.Duration param values, as parsed by time.ParseDuration, e.g. "1h30m".
+const DurationPattern = `^[-+]?(0|([0-9]*(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+)$`
+
+var timeType = reflect.TypeFor[time.Time]()
+var durationType = reflect.TypeFor[time.Duration]()
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+var paramSchemaProviderType = reflect.TypeFor[ParamSchemaProvider]()
+
+// IsTextUnmarshaler checks if the given type, or a pointer to it, implements encoding.TextUnmarshaler.
+func IsTextUnmarshaler(t reflect.Type) bool {
+	return t.Implements(textUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// ParamSchema returns the OpenAPI schema of the given type if it, or a pointer to it, implements ParamSchemaProvider, or
+// if it is a time.Time or time.Duration.
+func ParamSchema(t reflect.Type) *openapi3.Schema {
+	if t == timeType {
+		return openapi3.NewDateTimeSchema()
+	} else if t == durationType {
+		return openapi3.NewStringSchema().WithPattern(DurationPattern)
+	}
+	if t.Implements(paramSchemaProviderType) {
+		return reflect.Zero(t).Interface().(ParamSchemaProvider).ParamSchema()
+	}
+	if reflect.PointerTo(t).Implements(paramSchemaProviderType) {
+		return reflect.New(t).Interface().(ParamSchemaProvider).ParamSchema()
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"net"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+type schemaProvider string
+
+func (*schemaProvider) UnmarshalText([]byte) error { return nil }
+
+func (*schemaProvider) ParamSchema() *openapi3.Schema { return openapi3.NewUUIDSchema() }
+
+func TestIsTextUnmarshaler(t *testing.T) {
+	tests := []struct {
+		name string
+		t    reflect.Type
+		want bool
+	}{
+		{"F:IsTextUnmarshaler(<string>)", reflect.TypeOf(""), false},
+		{"T:IsTextUnmarshaler(<time.Time>)", reflect.TypeOf(time.Time{}), true},
+		{"T:IsTextUnmarshaler(<net.IP>)", reflect.TypeOf(net.IP{}), true},
+		{"T:IsTextUnmarshaler(<schemaProvider>)", reflect.TypeOf(schemaProvider("")), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTextUnmarshaler(tt.t); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParamSchema(t *testing.T) {
+	a := assert.New(t)
+	a.Nil(ParamSchema(reflect.TypeOf(netip.Addr{})))
+	a.Equal(openapi3.NewUUIDSchema(), ParamSchema(reflect.TypeOf(schemaProvider(""))))
+	a.Equal(openapi3.NewDateTimeSchema(), ParamSchema(reflect.TypeOf(time.Time{})))
+	a.Equal("date", ParamSchema(reflect.TypeOf(Date{})).Format)
+	a.Equal(DurationPattern, ParamSchema(reflect.TypeOf(time.Duration(0))).Pattern)
+}
@@ -0,0 +1,231 @@
+// Package paramsgen is the runtime support of the params populate functions, params and field docs generated by
+// xfuego-gen, see github.com/crunk1/xfuego/cmd/xfuego-gen.
+//
+// It is only meant to be used by the code generated by xfuego-gen, not by hand. Its API is not covered by the
+// compatibility of xfuego's: it follows the generator, and may change in any release of xfuego, along with the code
+// generated for it. Code generated by an xfuego-gen version is only supported with the xfuego version of that
+// xfuego-gen: run go generate again after upgrading xfuego.
+//
+// A generated populate function gets each param with the getter of its location, converts and validates it with the
+// same functions and errors as xfuego's reflective populator, and returns ParamErrors listing every invalid param. The
+// generated params of a struct are documented by xfuego with the same route options as its parsed params.
+package paramsgen
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/crunk1/xfuego/internal/field"
+	"github.com/crunk1/xfuego/internal/paramspopulator"
+	"github.com/crunk1/xfuego/internal/paramsrouteoptions"
+)
+
+// Getters is the part of the request context a populate function reads params from.
+type Getters = paramspopulator.ContextGetters
+
+// ParamError and ParamErrors are xfuego.ParamError and xfuego.ParamErrors.
+type ParamError = paramspopulator.ParamError
+type ParamErrors = paramspopulator.ParamErrors
+
+// In is the location of a param, see ParamError.
+type In = field.In
+
+const (
+	InQuery  = field.InQuery
+	InPath   = field.InPath
+	InHeader = field.InHeader
+	InCookie = field.InCookie
+	InForm   = field.InForm
+)
+
+// ErrMissing is the reason of the ParamError of a missing required param.
+var ErrMissing = paramspopulator.ErrMissing
+
+// Register registers the generated populate function and params of ParamsT, used by xfuego instead of reflection for
+// the routes and groups with ParamsT params: the params are documented as if parsed from ParamsT. fingerprint
+// describes the ParamsT fields they were generated from: route registration fails with a *xfuego.ParamsStructError
+// if ParamsT has changed since, until they are generated again.
+func Register[ParamsT any](populate func(Getters, *ParamsT) error, params []Param, fingerprint string) {
+	paramspopulator.Register(populate, fingerprint)
+	fieldParams := make([]field.Param, len(params))
+	for i, p := range params {
+		fieldParams[i] = p.fieldParam()
+	}
+	paramsrouteoptions.Register[ParamsT](fieldParams)
+}
+
+// Param is a param of a params struct, as parsed from its field by xfuego. Only the bool, string and number params
+// supported by xfuego-gen, their pointers and slices, can be described.
+type Param struct {
+	Field string // The name of the param field.
+	In    In
+	Name  string
+	Desc  string
+	Type  reflect.Type // The type of the param, or of its elements for slices.
+	Slice bool
+
+	Required   bool
+	Deprecated bool
+
+	// Style and Explode are the OpenAPI serialization of slice params.
+	Style   string
+	Explode *bool
+
+	// Default, Examples and Enum values have the param Type. The Default of a slice param is a []any.
+	Default  any
+	Examples map[string]any
+	Enum     []any
+
+	Min        *float64
+	Max        *float64
+	MultipleOf *float64
+	MinLength  *uint64
+	MaxLength  *uint64
+	Pattern    *regexp.Regexp
+}
+
+func (p Param) fieldParam() field.Param {
+	return field.Param{
+		Field:        reflect.StructField{Name: p.Field},
+		In:           p.In,
+		GoType:       p.Type,
+		GoKind:       p.Type.Kind(),
+		Slice:        p.Slice,
+		Required:     p.Required,
+		Name:         p.Name,
+		Desc:         p.Desc,
+		Deprecated:   p.Deprecated,
+		Style:        p.Style,
+		Explode:      p.Explode,
+		DefaultValue: p.Default,
+		Examples:     p.Examples,
+		Min:          p.Min,
+		Max:          p.Max,
+		MultipleOf:   p.MultipleOf,
+		MinLength:    p.MinLength,
+		MaxLength:    p.MaxLength,
+		Pattern:      p.Pattern,
+		Enum:         p.Enum,
+	}
+}
+
+// Ptr returns a pointer to v, for the optional values of Param.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// RegisterDocs registers the field doc comments of StructT, a params struct or one of its embedded or nested structs,
+// keyed by field name. They describe the params, in the OpenAPI spec, whose tag has no description.
+func RegisterDocs[StructT any](docs map[string]string) {
+	field.RegisterDocs(reflect.TypeFor[StructT](), docs)
+}
+
+// NewParamError returns the ParamError of a param that could not be populated.
+func NewParamError(name string, in In, err error) ParamError {
+	return paramspopulator.NewParamError(name, in, err)
+}
+
+// Query, Path, Header, Cookie and Form return the value of a param, and whether it is present in the request.
+
+func Query(c Getters, name string) (string, bool) {
+	return paramspopulator.QueryValue(c, name)
+}
+
+func Path(c Getters, name string) (string, bool) {
+	return paramspopulator.PathValue(c, name)
+}
+
+func Header(c Getters, name string) (string, bool) {
+	return paramspopulator.HeaderValue(c, name)
+}
+
+func Cookie(c Getters, name string) (string, bool) {
+	return paramspopulator.CookieValue(c, name)
+}
+
+func Form(c Getters, name string) (string, bool) {
+	return paramspopulator.FormValue(c, name)
+}
+
+// QueryValues, HeaderValues, CookieValues and FormValues return all the raw values of a slice param, and whether it is
+// present in the request. Delimited values are split with SplitLists.
+
+func QueryValues(c Getters, name string) ([]string, bool) {
+	return paramspopulator.QueryValues(c, name)
+}
+
+func HeaderValues(c Getters, name string) ([]string, bool) {
+	return paramspopulator.HeaderValues(c, name)
+}
+
+func CookieValues(c Getters, name string) ([]string, bool) {
+	return paramspopulator.CookieValues(c, name)
+}
+
+func FormValues(c Getters, name string) ([]string, bool) {
+	return paramspopulator.FormValues(c, name)
+}
+
+// SplitLists splits delimited values, trimming optional whitespace around the elements.
+func SplitLists(values []string, delimiter string) []string {
+	return paramspopulator.SplitLists(values, delimiter)
+}
+
+// ParseString, ParseBool, ParseInt, ParseUint and ParseFloat convert a single param value.
+
+func ParseString(value string) (string, error) {
+	return value, nil
+}
+
+func ParseBool(value string) (bool, error) {
+	return field.ParseBool(value)
+}
+
+func ParseInt[T int | int8 | int16 | int32 | int64](value string) (T, error) {
+	return field.ParseInt[T](value)
+}
+
+func ParseUint[T uint | uint8 | uint16 | uint32 | uint64](value string) (T, error) {
+	return field.ParseUint[T](value)
+}
+
+func ParseFloat[T float32 | float64](value string) (T, error) {
+	return field.ParseFloat[T](value)
+}
+
+// IsMultipleOf reports whether f is a multiple of multipleOf, within floating point precision.
+func IsMultipleOf(f, multipleOf float64) bool {
+	return field.IsMultipleOf(f, multipleOf)
+}
+
+// MinError, MaxError, MultipleOfError, MinLengthError, MaxLengthError, PatternError and EnumError are the reasons of
+// the ParamError of a param value violating a validation constraint of its tag.
+
+func MinError(raw string, min float64) error {
+	return field.MinError(raw, min)
+}
+
+func MaxError(raw string, max float64) error {
+	return field.MaxError(raw, max)
+}
+
+func MultipleOfError(raw string, multipleOf float64) error {
+	return field.MultipleOfError(raw, multipleOf)
+}
+
+func MinLengthError(raw string, minLength uint64) error {
+	return field.MinLengthError(raw, minLength)
+}
+
+func MaxLengthError(raw string, maxLength uint64) error {
+	return field.MaxLengthError(raw, maxLength)
+}
+
+func PatternError(raw string, pattern *regexp.Regexp) error {
+	return field.PatternError(raw, pattern)
+}
+
+// EnumError takes the enum values of the tag, e.g. "asc|desc".
+func EnumError(raw string, enum string) error {
+	return field.EnumError(raw, enum)
+}
@@ -0,0 +1,73 @@
+package xfuego
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+
+	"github.com/go-fuego/fuego"
+
+	"github.com/crunk1/xfuego/internal/paramsrouteoptions"
+	"github.com/crunk1/xfuego/internal/responseheaders"
+)
+
+// Response is a response body with typed response headers and cookies. Controllers return it instead of their BodyT,
+// e.g. `func MyController(req xfuego.Request[Params, Body]) (xfuego.Response[Headers, RespBody], error)`.
+//
+// HeadersT is a struct of `header` and `cookie` fields, with the same types and tags as params structs (except maps and
+// default values). They are written to the response when the controller succeeds, and documented as response headers
+// of the route's default status code response; nil optional fields are not written. Cookies are written as plain
+// `name=value` cookies; cookies with attributes are set with SetCookie. If a field value cannot be formatted, i.e. if
+// its MarshalText method fails, no field is written and the request fails with a 500 error.
+//
+// Body is serialized as BodyT, and documented as the response content.
+type Response[HeadersT any, BodyT any] struct {
+	Headers HeadersT
+	Body    BodyT
+}
+
+func (r Response[HeadersT, BodyT]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Body)
+}
+
+func (r Response[HeadersT, BodyT]) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	return e.Encode(r.Body)
+}
+
+func (r Response[HeadersT, BodyT]) MarshalYAML() (any, error) {
+	return r.Body, nil
+}
+
+func (r Response[HeadersT, BodyT]) String() string {
+	return fmt.Sprint(r.Body)
+}
+
+// OutTransform calls the fuego.OutTransformer implementation of Body, if any.
+func (r *Response[HeadersT, BodyT]) OutTransform(ctx context.Context) error {
+	if transformer, ok := any(r.Body).(fuego.OutTransformer); ok {
+		return transformer.OutTransform(ctx)
+	} else if transformer, ok := any(&r.Body).(fuego.OutTransformer); ok {
+		return transformer.OutTransform(ctx)
+	}
+	return nil
+}
+
+// responseWithHeaders is implemented by *Response, for the registration of routes returning a Response.
+type responseWithHeaders interface {
+	routeOption() (func(*fuego.BaseRoute), error)
+	headersWriter() func(w http.ResponseWriter, resp any) error
+}
+
+func (*Response[HeadersT, BodyT]) routeOption() (func(*fuego.BaseRoute), error) {
+	return paramsrouteoptions.GenerateResponse[HeadersT, BodyT]()
+}
+
+func (*Response[HeadersT, BodyT]) headersWriter() func(w http.ResponseWriter, resp any) error {
+	writeHeaders := responseheaders.Generate[HeadersT]()
+	return func(w http.ResponseWriter, resp any) error {
+		r := resp.(Response[HeadersT, BodyT])
+		return writeHeaders(w, &r.Headers)
+	}
+}
@@ -0,0 +1,280 @@
+package xfuego
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/go-fuego/fuego"
+
+	"github.com/crunk1/xfuego/internal/paramsrouteoptions"
+)
+
+// StatusCoder is an outcome of a controller returning OneOf2, OneOf3 or OneOf4: a response body with its status code.
+// StatusCode is also called on the zero value to document the outcome, so it must not depend on the value.
+//
+// OK, Created, Accepted and NoContent wrap a body with a standard status code; other status codes are declared by
+// body types implementing StatusCoder, e.g. `func (PartialUpload) StatusCode() int { return 206 }`.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// OK is a 200 response body.
+type OK[BodyT any] struct {
+	Body BodyT
+}
+
+func (OK[BodyT]) StatusCode() int {
+	return http.StatusOK
+}
+
+func (o OK[BodyT]) responseBody() any {
+	return o.Body
+}
+
+// Created is a 201 response body.
+type Created[BodyT any] struct {
+	Body BodyT
+}
+
+func (Created[BodyT]) StatusCode() int {
+	return http.StatusCreated
+}
+
+func (o Created[BodyT]) responseBody() any {
+	return o.Body
+}
+
+// Accepted is a 202 response body.
+type Accepted[BodyT any] struct {
+	Body BodyT
+}
+
+func (Accepted[BodyT]) StatusCode() int {
+	return http.StatusAccepted
+}
+
+func (o Accepted[BodyT]) responseBody() any {
+	return o.Body
+}
+
+// NoContent is a 204 response, without a body.
+type NoContent struct{}
+
+func (NoContent) StatusCode() int {
+	return http.StatusNoContent
+}
+
+func (NoContent) responseBody() any {
+	return nil
+}
+
+// OneOf2 is a controller result that is one of two declared outcomes, e.g.
+// `xfuego.OneOf2[xfuego.OK[User], xfuego.Created[User]]`, each documented as a response of the route. The controller
+// chooses the outcome with With1 or With2, e.g. `return result{}.With2(xfuego.Created[User]{Body: user}), nil` for a
+// `type result = xfuego.OneOf2[...]` alias.
+//
+// The outcome's status code is written instead of the route's default status code, which is the first outcome's.
+type OneOf2[T1, T2 StatusCoder] struct {
+	outcome
+}
+
+func (OneOf2[T1, T2]) With1(v T1) OneOf2[T1, T2] {
+	return OneOf2[T1, T2]{outcome{v}}
+}
+
+func (OneOf2[T1, T2]) With2(v T2) OneOf2[T1, T2] {
+	return OneOf2[T1, T2]{outcome{v}}
+}
+
+func (OneOf2[T1, T2]) variants() []StatusCoder {
+	return []StatusCoder{*new(T1), *new(T2)}
+}
+
+// OneOf3 is OneOf2 with three declared outcomes.
+type OneOf3[T1, T2, T3 StatusCoder] struct {
+	outcome
+}
+
+func (OneOf3[T1, T2, T3]) With1(v T1) OneOf3[T1, T2, T3] {
+	return OneOf3[T1, T2, T3]{outcome{v}}
+}
+
+func (OneOf3[T1, T2, T3]) With2(v T2) OneOf3[T1, T2, T3] {
+	return OneOf3[T1, T2, T3]{outcome{v}}
+}
+
+func (OneOf3[T1, T2, T3]) With3(v T3) OneOf3[T1, T2, T3] {
+	return OneOf3[T1, T2, T3]{outcome{v}}
+}
+
+func (OneOf3[T1, T2, T3]) variants() []StatusCoder {
+	return []StatusCoder{*new(T1), *new(T2), *new(T3)}
+}
+
+// OneOf4 is OneOf2 with four declared outcomes.
+type OneOf4[T1, T2, T3, T4 StatusCoder] struct {
+	outcome
+}
+
+func (OneOf4[T1, T2, T3, T4]) With1(v T1) OneOf4[T1, T2, T3, T4] {
+	return OneOf4[T1, T2, T3, T4]{outcome{v}}
+}
+
+func (OneOf4[T1, T2, T3, T4]) With2(v T2) OneOf4[T1, T2, T3, T4] {
+	return OneOf4[T1, T2, T3, T4]{outcome{v}}
+}
+
+func (OneOf4[T1, T2, T3, T4]) With3(v T3) OneOf4[T1, T2, T3, T4] {
+	return OneOf4[T1, T2, T3, T4]{outcome{v}}
+}
+
+func (OneOf4[T1, T2, T3, T4]) With4(v T4) OneOf4[T1, T2, T3, T4] {
+	return OneOf4[T1, T2, T3, T4]{outcome{v}}
+}
+
+func (OneOf4[T1, T2, T3, T4]) variants() []StatusCoder {
+	return []StatusCoder{*new(T1), *new(T2), *new(T3), *new(T4)}
+}
+
+// outcome is the chosen outcome of a OneOf result, serialized as its response body.
+type outcome struct {
+	value StatusCoder
+}
+
+func (o outcome) statusCoder() StatusCoder {
+	return o.value
+}
+
+func (o outcome) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.body())
+}
+
+func (o outcome) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	return e.Encode(o.body())
+}
+
+func (o outcome) MarshalYAML() (any, error) {
+	return o.body(), nil
+}
+
+func (o outcome) String() string {
+	return fmt.Sprint(o.body())
+}
+
+// OutTransform calls the fuego.OutTransformer implementation of the response body, if any.
+func (o *outcome) OutTransform(ctx context.Context) error {
+	if transformer, ok := o.body().(fuego.OutTransformer); ok {
+		return transformer.OutTransform(ctx)
+	}
+	return nil
+}
+
+func (o outcome) body() any {
+	return responseBody(o.value)
+}
+
+// responseBody returns the body of an outcome: the wrapped body of OK, Created, etc., or the outcome itself.
+func responseBody(v StatusCoder) any {
+	if b, ok := v.(interface{ responseBody() any }); ok {
+		return b.responseBody()
+	}
+	return v
+}
+
+// statusResult is implemented by OneOf2, OneOf3 and OneOf4, for the registration of routes returning them.
+type statusResult interface {
+	statusCoder() StatusCoder
+	variants() []StatusCoder
+}
+
+// statusResultRouteOptions returns the route options of a route whose controller returns a statusResult: the
+// documentation of its outcomes, and the middleware writing the status code of the chosen outcome.
+func statusResultRouteOptions(result statusResult) ([]func(*fuego.BaseRoute), error) {
+	var responses []paramsrouteoptions.StatusResponse
+	for _, v := range result.variants() {
+		responses = append(responses, paramsrouteoptions.StatusResponse{StatusCode: v.StatusCode(), Body: responseBody(v)})
+	}
+	opt, err := paramsrouteoptions.GenerateStatusResponses(reflect.TypeOf(result), responses)
+	if err != nil {
+		return nil, err
+	}
+	return []func(*fuego.BaseRoute){opt, fuego.OptionMiddleware(statusMiddleware)}, nil
+}
+
+// errNoOutcome is returned for a OneOf result without an outcome, e.g. a zero OneOf2.
+var errNoOutcome = errors.New("xfuego: the controller result has no outcome, see OneOf2.With1")
+
+// setResultStatus sets the status code of the outcome of a statusResult, written by statusMiddleware.
+func setResultStatus(w http.ResponseWriter, result statusResult) error {
+	v := result.statusCoder()
+	if v == nil {
+		return fuego.InternalServerError{Err: errNoOutcome, Detail: errNoOutcome.Error(), Status: http.StatusInternalServerError}
+	}
+	if sw, ok := w.(*statusWriter); ok {
+		sw.statusCode = v.StatusCode()
+	}
+	return nil
+}
+
+// statusMiddleware replaces the response writer with a statusWriter.
+func statusMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, r)
+		sw.writeHeader()
+	})
+}
+
+// statusWriter writes the status code of a controller's outcome instead of the route's default status code, which
+// fuego writes before serializing the response. The status code is written along with the body, so that the headers
+// set by the serialization, e.g. Content-Type, are written too.
+type statusWriter struct {
+	http.ResponseWriter
+	statusCode int // The status code of the outcome, if the controller succeeded.
+	pending    int // The last status code written by fuego, if any.
+	written    int // The status code written to the response, once written.
+}
+
+func (w *statusWriter) WriteHeader(statusCode int) {
+	if w.written == 0 {
+		w.pending = statusCode
+	}
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	w.writeHeader()
+	if !bodyAllowed(w.written) {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// writeHeader writes the status code, if not already written: the outcome's, unless fuego wrote an error status code.
+func (w *statusWriter) writeHeader() {
+	if w.written != 0 {
+		return
+	}
+	statusCode := w.pending
+	if w.statusCode != 0 && statusCode < http.StatusBadRequest {
+		statusCode = w.statusCode
+	}
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.written = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// bodyAllowed reports whether a response with the status code can have a body.
+func bodyAllowed(statusCode int) bool {
+	return statusCode != http.StatusNoContent && statusCode != http.StatusNotModified
+}
@@ -0,0 +1,77 @@
+package xfuego_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-fuego/fuego"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/crunk1/xfuego"
+)
+
+type item struct {
+	Name string `json:"name"`
+}
+
+func (i *item) OutTransform(context.Context) error {
+	if i.Name == "secret" {
+		return fuego.ForbiddenError{Detail: "secret item"}
+	}
+	return nil
+}
+
+type itemResult = xfuego.OneOf3[xfuego.OK[*item], xfuego.Created[*item], xfuego.NoContent]
+
+type itemParams struct {
+	Outcome string `query:"outcome"`
+	Name    string `query:"name,,default=a"`
+}
+
+func TestOneOf(t *testing.T) {
+	s := newServer()
+	xfuego.Put(s, "/items", func(req xfuego.Request[itemParams, xfuego.None]) (itemResult, error) {
+		it := &item{Name: req.Params().Name}
+		switch req.Params().Outcome {
+		case "ok":
+			return itemResult{}.With1(xfuego.OK[*item]{Body: it}), nil
+		case "created":
+			return itemResult{}.With2(xfuego.Created[*item]{Body: it}), nil
+		case "none":
+			return itemResult{}.With3(xfuego.NoContent{}), nil
+		case "error":
+			return itemResult{}, fuego.ConflictError{Detail: "conflict"}
+		}
+		return itemResult{}, nil
+	})
+
+	tests := []struct {
+		name     string
+		target   string
+		wantCode int
+		wantBody string // Expected JSON body, if any.
+	}{
+		{"first outcome", "/items?outcome=ok", http.StatusOK, `{"name": "a"}`},
+		{"other outcome", "/items?outcome=created", http.StatusCreated, `{"name": "a"}`},
+		{"no content drops the body", "/items?outcome=none", http.StatusNoContent, ""},
+		{"zero result", "/items?outcome=zero", http.StatusInternalServerError, ""},
+		{"controller error", "/items?outcome=error", http.StatusConflict, ""},
+		{"serialization error wins over the outcome", "/items?outcome=created&name=secret", http.StatusForbidden, ""},
+		{"params error", "/items", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(s, http.MethodPut, tt.target)
+			assert.Equal(t, tt.wantCode, w.Code, w.Body.String())
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+				assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			} else if tt.wantCode == http.StatusNoContent {
+				assert.Empty(t, w.Body.String())
+			} else {
+				assert.Equal(t, tt.wantCode, decodeProblem(t, w).Status)
+			}
+		})
+	}
+}
@@ -1,24 +1,86 @@
 // Package xfuego is an adapter layer around github.com/go-fuego/fuego route registration functions (Get, Post, etc.)
 // that adds request parameter typing to request controllers. It supports all the parameter types and options as
-// provided by fuego: query/path/header/cookie params, bools/ints/strings, optionality, nullability, default values.
+// provided by fuego: query/path/header/cookie params, bools/numbers/strings, optionality, nullability, default values.
 //
 // Similar to how body type is defined in fuego controller functions, parameters are also defined in controller function
 // signatures. Parameters are defined in a struct; each struct field is a parameter; parameter options are determined by
 // each struct field's type and tag:
 //   - example function signature: `func MyController(req xfuego.Request[Params, Body]) (RespBody, error)`
-//   - Base types: bool, int, string (required unless a default value is provided)
-//   - Optional types: *bool, *int, *string
-//   - Nullable types: xfuego.Nullable[bool], xfuego.Nullable[int], xfuego.Nullable[string]
-//   - Optional and nullable types: *Nullable[bool], *Nullable[int], *Nullable[string]
-//   - Parameter tags: `{query,path,header,cookie}:"<name>,<description>,<additional options>"`
-//   - {query,path,header,cookie} is the parameter `in` value.
+//   - Base types: bool, string, and all numeric types: int*, uint*, float* (required unless a default value is provided);
+//     the default, example and enum values of uint and uint64 params must not exceed the OpenAPI integer range (int64)
+//   - Text types: any type implementing encoding.TextUnmarshaler, e.g. `type OrderID string` with an UnmarshalText method
+//   - Time types: time.Time (RFC 3339 unless a `layout=<Go time layout>` option is given), xfuego.Date, time.Duration
+//   - Optional types: pointers to any of the above, e.g. *bool, *uint32, *float64, *string, *time.Time
+//   - Nullable types: xfuego.Nullable[T] for any of the above T, e.g. xfuego.Nullable[int], xfuego.Nullable[float32]
+//   - Optional and nullable types: *Nullable[T], e.g. *Nullable[bool], *Nullable[int64], *Nullable[string]
+//   - Slice types: []bool, []int, []string, etc. and optional *[]int etc. (query, header, cookie and form params only)
+//   - query and form values are repeated (`?tag=a&tag=b`), header and cookie values are comma-separated
+//     (`X-Tags: a,b`), and header lines may be repeated
+//   - slice default/example values are `|`-separated, e.g. `default=1|2|3`
+//   - Map types: map[string]T, e.g. `query:"label."` collects `?label.env=prod&label.team=core` as
+//     {"env": "prod", "team": "core"}; maps are optional and also supported for header and cookie prefixes
+//   - Embedded structs and *structs: their params are promoted, e.g. a shared `Pagination` struct; param names must be
+//     unique per location across the params struct and its embedded structs
+//   - Nested struct query params: a struct field tagged e.g. `query:"filter"` binds its own query params as
+//     `?filter[status]=x` (OpenAPI style deepObject), or `?filter.status=x` with a `separator=.` option
+//   - Form params: `form:"user"` fields are bound from an application/x-www-form-urlencoded body, like query params, and
+//     documented as the request body schema; the route's body type must be None
+//   - Parameter tags: `{query,path,header,cookie,form}:"<name>,<description>,<additional options>"`
+//   - {query,path,header,cookie} is the parameter `in` value, form for form body fields.
 //   - <name> is the name of the parameter, if omitted, the struct field name is used.
 //   - <additional options> is a comma-separated list of options: `default=<default value>`, `example=<example name>=<example value>`
+//   - slice params also accept `style=<OpenAPI style>` and `explode=<bool>`, e.g. `query:"ids,,style=pipeDelimited"`
+//     for `?ids=1|2|3`
+//   - validation options, also documented in the OpenAPI schema: `min=`, `max=`, `multipleOf=` for numbers,
+//     `minLength=`, `maxLength=`, `pattern=` for strings and text types, and `enum=asc|desc` for any type
+//   - `alias=page_size` also reads the param from a former name, documented as a deprecated param, and `deprecated`
+//     documents the param as deprecated; requests using either get a `Deprecation: true` response header
+//   - the description and option values may be single-quoted to contain commas, e.g. `default='a,b'`, or set with
+//     their own `desc:"..."`, `default:"..."` and `example:"<example name>=<example value>"` tags
+//
+// Handle registers a route for any HTTP method, including HEAD, TRACE and custom methods.
+//
+// Routes can be registered on a fuego.Group, whose path prefix, route options and tags apply as usual; params declared
+// by the group's options are replaced by the params struct's declaration of the same param.
+//
+// Params shared by every route of a group are declared once with Group[GroupParamsT], which documents them on the
+// group's routes, including those of groups nested in it, and populates them with each route's params; controllers
+// read them with GroupParams.
+//
+// The path params of a params struct must match the `{name}` wildcards of the full route path, and every wildcard of
+// the route's own path (not its group prefix) must have a path param; route registration panics with the list of
+// mismatches otherwise. TryGet, TryPost, etc. return a *ParamsStructError
+// listing every invalid field of the params struct instead of panicking.
+//
+// Missing required params and param values that cannot be converted to their field's type or fail their validation
+// options are rejected before the controller is called, with a 400 fuego.BadRequestError listing every invalid param's
+// name, `in` location and reason.
+//
+// Rules spanning several params are checked by implementing ParamsValidator or ParamsContextValidator on the params
+// struct. Validate is called after the params are populated; a ParamError or ParamErrors it returns is listed per param
+// in a 400 response, errors with an HTTP status (e.g. a 422 fuego.HTTPError) are sent as is, and other errors are sent
+// as a 400 response.
+//
+// Controllers return typed response headers and cookies with Response[HeadersT, BodyT]: the header and cookie fields
+// of HeadersT are written to the response and documented as response headers.
+//
+// Controllers with several outcomes return OneOf2, OneOf3 or OneOf4, whose type params declare the status code and
+// body of each outcome, e.g. `OneOf2[OK[User], Created[User]]`; every outcome is documented as a response of the route.
+//
+// Request.ParamSource reports whether a param was provided in the request, set to its default value, missing, or
+// provided as "null".
+//
+// Params structs are populated with reflection, or by the plain Go functions generated for them by cmd/xfuego-gen,
+// which also registers the doc comments of param fields as the descriptions of the params whose tag has none.
 //
 // Package xfuego also introduces the following types:
 //   - `xfuego.Request[Params, Body]` is a wrapper around `fuego.ContextWithBody[Body]` and adds a Params type.
 //   - request controllers registering through xfuego must use this instead of `fuego.ContextWithBody[Body]`.
+//   - `xfuego.Response[Headers, Body]` is a response body with typed response headers and cookies.
+//   - `xfuego.OneOf2[T1, T2]`, `xfuego.OneOf3` and `xfuego.OneOf4` are controller results with several declared outcomes.
 //   - `xfuego.Nullable[T]` is a `*T` that indicates that a parameter is nullable. Null values are represented as `nil`.
+//   - `xfuego.Date` is a date-only param value, e.g. "2025-01-31".
+//   - `xfuego.ParamSchemaProvider` lets text param types override their OpenAPI schema (a string by default).
 //   - `xfuego.None` is a type that indicates that a request's params and/or body are not used.
 //   - e.g. `func MyController(req xfuego.Request[xfuego.None, Body]) (RespBody, error)`
 //
@@ -43,8 +105,14 @@
 package xfuego
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+
 	"github.com/go-fuego/fuego"
 
+	"github.com/crunk1/xfuego/internal/field"
 	"github.com/crunk1/xfuego/internal/paramspopulator"
 	"github.com/crunk1/xfuego/internal/paramsrouteoptions"
 	"github.com/crunk1/xfuego/internal/types"
@@ -54,17 +122,36 @@ type Request[ParamsT any, BodyT any] interface {
 	fuego.ContextWithBody[BodyT]
 
 	Params() ParamsT
+
+	// ParamSource reports whether a param of the route or its groups was provided in the request (under its name or an
+	// alias), set to its default value, missing, or provided as "null". The param is named as in its tag, e.g. "limit",
+	// or qualified with its location, e.g. "header:X-Limit", when params of different locations share a name. It panics
+	// if the route has no such param.
+	ParamSource(name string) ParamSource
 }
 
 type request[ParamsT any, BodyT any] struct {
 	fuego.ContextWithBody[BodyT]
-	params ParamsT
+	params      ParamsT
+	groupParams []any // The *GroupParamsT of the route's groups, innermost first, see GroupParams.
+
+	// paramSources are the source functions of the route's params and of its groups' params, innermost first.
+	paramSources []func(c paramspopulator.ContextGetters, name string) (ParamSource, bool)
 }
 
 func (r *request[ParamsT, BodyT]) Params() ParamsT {
 	return r.params
 }
 
+func (r *request[ParamsT, BodyT]) ParamSource(name string) ParamSource {
+	for _, paramSource := range r.paramSources {
+		if source, ok := paramSource(r, name); ok {
+			return source
+		}
+	}
+	panic(fmt.Sprintf("xfuego: the route has no param %q", name))
+}
+
 type RequestController[ReqParamsT any, ReqBodyT any, RespBodyT any] func(Request[ReqParamsT, ReqBodyT]) (RespBodyT, error)
 
 type Nullable[T any] = types.Nullable[T]
@@ -72,46 +159,304 @@ type Nullable[T any] = types.Nullable[T]
 // None is used to indicate that a request's params and/or body are not used.
 type None = types.None
 
+// Date is a date-only param value, e.g. "2025-01-31". It is documented as an OpenAPI string with format date.
+type Date = types.Date
+
+// ParamSchemaProvider can be implemented by encoding.TextUnmarshaler param types to override their OpenAPI schema,
+// which is a plain string schema by default.
+type ParamSchemaProvider = types.ParamSchemaProvider
+
+// ParamsStructError lists every invalid field of a params struct, as returned by TryGet, TryPost, etc. The
+// registration funcs without the Try prefix panic with it instead.
+type ParamsStructError = field.StructError
+
+// ParamsValidator can be implemented by params structs to check rules spanning several params. It is called after
+// the params are populated; a returned error is sent as a 400 response, see ParamError.
+type ParamsValidator = paramspopulator.Validator
+
+// ParamsContextValidator is the context-aware variant of ParamsValidator.
+type ParamsContextValidator = paramspopulator.ContextValidator
+
+// ParamError is an invalid param, as listed in 400 responses. Validate methods can return a ParamError or ParamErrors
+// to report field-scoped errors; the In location is filled in from the param Name.
+type ParamError = paramspopulator.ParamError
+
+// ParamErrors lists several invalid params.
+type ParamErrors = paramspopulator.ParamErrors
+
+// ParamSource is how a request param got its value, see Request.ParamSource.
+type ParamSource = paramspopulator.Source
+
+const (
+	ParamMissing   = paramspopulator.SourceMissing   // Not in the request, and without a default value.
+	ParamProvided  = paramspopulator.SourceProvided  // In the request.
+	ParamDefaulted = paramspopulator.SourceDefaulted // Not in the request, and set to its default value.
+	ParamNull      = paramspopulator.SourceNull      // In the request as "null", for a Nullable param.
+)
+
 func All[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) *fuego.Route[RespBodyT, ReqBodyT] {
-	paramsRouteOptions := paramsrouteoptions.Generate[ReqParamsT]()
-	return fuego.All(s, path, wrapController(controller), append(opts, paramsRouteOptions...)...)
+	return mustRegister(TryAll(s, path, controller, opts...))
 }
 
 func Get[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) *fuego.Route[RespBodyT, ReqBodyT] {
-	paramsRouteOptions := paramsrouteoptions.Generate[ReqParamsT]()
-	return fuego.Get(s, path, wrapController(controller), append(opts, paramsRouteOptions...)...)
+	return mustRegister(TryGet(s, path, controller, opts...))
 }
 
 func Post[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) *fuego.Route[RespBodyT, ReqBodyT] {
-	paramsRouteOptions := paramsrouteoptions.Generate[ReqParamsT]()
-	return fuego.Post(s, path, wrapController(controller), append(opts, paramsRouteOptions...)...)
+	return mustRegister(TryPost(s, path, controller, opts...))
 }
 
 func Delete[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) *fuego.Route[RespBodyT, ReqBodyT] {
-	paramsRouteOptions := paramsrouteoptions.Generate[ReqParamsT]()
-	return fuego.Delete(s, path, wrapController(controller), append(opts, paramsRouteOptions...)...)
+	return mustRegister(TryDelete(s, path, controller, opts...))
 }
 
 func Put[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) *fuego.Route[RespBodyT, ReqBodyT] {
-	paramsRouteOptions := paramsrouteoptions.Generate[ReqParamsT]()
-	return fuego.Put(s, path, wrapController(controller), append(opts, paramsRouteOptions...)...)
+	return mustRegister(TryPut(s, path, controller, opts...))
 }
 
 func Patch[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) *fuego.Route[RespBodyT, ReqBodyT] {
-	paramsRouteOptions := paramsrouteoptions.Generate[ReqParamsT]()
-	return fuego.Patch(s, path, wrapController(controller), append(opts, paramsRouteOptions...)...)
+	return mustRegister(TryPatch(s, path, controller, opts...))
 }
 
 func Options[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) *fuego.Route[RespBodyT, ReqBodyT] {
-	paramsRouteOptions := paramsrouteoptions.Generate[ReqParamsT]()
-	return fuego.Options(s, path, wrapController(controller), append(opts, paramsRouteOptions...)...)
+	return mustRegister(TryOptions(s, path, controller, opts...))
+}
+
+// TryAll is All, returning a *ParamsStructError instead of panicking if ReqParamsT is invalid.
+func TryAll[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) (*fuego.Route[RespBodyT, ReqBodyT], error) {
+	return register(s, path, controller, opts, fuego.All[RespBodyT, ReqBodyT])
+}
+
+// TryGet is Get, returning a *ParamsStructError instead of panicking if ReqParamsT is invalid.
+func TryGet[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) (*fuego.Route[RespBodyT, ReqBodyT], error) {
+	return register(s, path, controller, opts, fuego.Get[RespBodyT, ReqBodyT])
+}
+
+// TryPost is Post, returning a *ParamsStructError instead of panicking if ReqParamsT is invalid.
+func TryPost[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) (*fuego.Route[RespBodyT, ReqBodyT], error) {
+	return register(s, path, controller, opts, fuego.Post[RespBodyT, ReqBodyT])
+}
+
+// TryDelete is Delete, returning a *ParamsStructError instead of panicking if ReqParamsT is invalid.
+func TryDelete[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) (*fuego.Route[RespBodyT, ReqBodyT], error) {
+	return register(s, path, controller, opts, fuego.Delete[RespBodyT, ReqBodyT])
+}
+
+// TryPut is Put, returning a *ParamsStructError instead of panicking if ReqParamsT is invalid.
+func TryPut[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) (*fuego.Route[RespBodyT, ReqBodyT], error) {
+	return register(s, path, controller, opts, fuego.Put[RespBodyT, ReqBodyT])
+}
+
+// TryPatch is Patch, returning a *ParamsStructError instead of panicking if ReqParamsT is invalid.
+func TryPatch[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) (*fuego.Route[RespBodyT, ReqBodyT], error) {
+	return register(s, path, controller, opts, fuego.Patch[RespBodyT, ReqBodyT])
 }
 
-func wrapController[ReqParamsT any, ReqBodyT any, RespBodyT any](controller RequestController[ReqParamsT, ReqBodyT, RespBodyT]) func(c fuego.ContextWithBody[ReqBodyT]) (RespBodyT, error) {
+// TryOptions is Options, returning a *ParamsStructError instead of panicking if ReqParamsT is invalid.
+func TryOptions[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) (*fuego.Route[RespBodyT, ReqBodyT], error) {
+	return register(s, path, controller, opts, fuego.Options[RespBodyT, ReqBodyT])
+}
+
+// Handle registers a route for any HTTP method, e.g. http.MethodHead, http.MethodTrace or a custom method such as
+// "PURGE"; an empty method matches all methods, as All. Routes with methods that OpenAPI does not define are
+// registered but hidden from the OpenAPI spec.
+func Handle[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, method string, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) *fuego.Route[RespBodyT, ReqBodyT] {
+	return mustRegister(TryHandle(s, method, path, controller, opts...))
+}
+
+// TryHandle is Handle, returning a *ParamsStructError instead of panicking if ReqParamsT is invalid.
+func TryHandle[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, method string, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts ...func(*fuego.BaseRoute)) (*fuego.Route[RespBodyT, ReqBodyT], error) {
+	return register(s, path, controller, opts, methodRegisterFn[RespBodyT, ReqBodyT](method))
+}
+
+// openAPIMethods are the HTTP methods of OpenAPI path item operations.
+var openAPIMethods = map[string]bool{
+	http.MethodConnect: true,
+	http.MethodDelete:  true,
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPatch:   true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodTrace:   true,
+}
+
+// methodRegisterFn returns a registration func for the method, like fuego.Get etc. for theirs. fuego does not export
+// one, so the route is registered with fuego.All and its method is set by a route option.
+func methodRegisterFn[RespBodyT any, ReqBodyT any](method string) func(*fuego.Server, string, func(fuego.ContextWithBody[ReqBodyT]) (RespBodyT, error), ...func(*fuego.BaseRoute)) *fuego.Route[RespBodyT, ReqBodyT] {
+	return func(s *fuego.Server, path string, controller func(fuego.ContextWithBody[ReqBodyT]) (RespBodyT, error), opts ...func(*fuego.BaseRoute)) *fuego.Route[RespBodyT, ReqBodyT] {
+		return fuego.All(s, path, controller, append(opts, func(r *fuego.BaseRoute) {
+			r.Method = method
+			if method != "" && !openAPIMethods[method] {
+				r.Hidden = true
+			}
+		})...)
+	}
+}
+
+// register registers a route with registerFn, e.g. fuego.Get, after checking ReqParamsT (and the HeadersT of a
+// Response RespBodyT, or the outcomes of a OneOf2 etc. RespBodyT) and generating its route options. It returns a
+// *ParamsStructError listing every invalid field instead of registering the route.
+func register[ReqParamsT any, ReqBodyT any, RespBodyT any](s *fuego.Server, path string, controller RequestController[ReqParamsT, ReqBodyT, RespBodyT], opts []func(*fuego.BaseRoute), registerFn func(*fuego.Server, string, func(fuego.ContextWithBody[ReqBodyT]) (RespBodyT, error), ...func(*fuego.BaseRoute)) *fuego.Route[RespBodyT, ReqBodyT]) (*fuego.Route[RespBodyT, ReqBodyT], error) {
+	// Checked first: the generated params used by paramsrouteoptions.Generate are up to date if the populate function is.
+	if err := paramspopulator.CheckGenerated[ReqParamsT](); err != nil {
+		return nil, err
+	}
+	paramsRouteOptions, err := paramsrouteoptions.Generate[ReqParamsT](basePath(s), path)
+	if err != nil {
+		return nil, err
+	}
+	if paramspopulator.GenerateParseForm[ReqParamsT]() != nil && !types.IsNoneType[ReqBodyT]() {
+		// The form params are the request body.
+		return nil, &ParamsStructError{Type: reflect.TypeOf((*ReqParamsT)(nil)).Elem(), Errors: []string{"form params require the None request body type"}}
+	}
+	if resp, ok := any(new(RespBodyT)).(responseWithHeaders); ok {
+		responseRouteOption, err := resp.routeOption()
+		if err != nil {
+			return nil, err
+		}
+		paramsRouteOptions = append(paramsRouteOptions, responseRouteOption)
+	}
+	if result, ok := any(*new(RespBodyT)).(statusResult); ok {
+		resultRouteOptions, err := statusResultRouteOptions(result)
+		if err != nil {
+			return nil, err
+		}
+		paramsRouteOptions = append(paramsRouteOptions, resultRouteOptions...)
+	}
+	wrappedController, groupsRouteOption := wrapController(controller)
+	// groupsRouteOption runs after the route options of the route's groups.
+	return registerFn(s, path, wrappedController, append(opts, append(paramsRouteOptions, groupsRouteOption)...)...), nil
+}
+
+func mustRegister[RespBodyT any, ReqBodyT any](route *fuego.Route[RespBodyT, ReqBodyT], err error) *fuego.Route[RespBodyT, ReqBodyT] {
+	if err != nil {
+		panic(err)
+	}
+	return route
+}
+
+// basePath returns the path prefix of the routes registered on s, e.g. the path of a fuego.Group, which fuego does not
+// expose. It is needed to check the path params of a route before fuego registers it.
+func basePath(s *fuego.Server) string {
+	v := reflect.ValueOf(s).Elem().FieldByName("basePath")
+	if v.Kind() != reflect.String {
+		return ""
+	}
+	return v.String()
+}
+
+// wrapController wraps controller as a fuego controller. The returned route option sets the route's groups, see Group,
+// and must run after the route options of the groups, at registration.
+func wrapController[ReqParamsT any, ReqBodyT any, RespBodyT any](controller RequestController[ReqParamsT, ReqBodyT, RespBodyT]) (func(c fuego.ContextWithBody[ReqBodyT]) (RespBodyT, error), func(*fuego.BaseRoute)) {
 	populateParams := paramspopulator.Generate[ReqParamsT]()
+	validateParams := paramspopulator.GenerateValidate[ReqParamsT]()
+	parseForm := paramspopulator.GenerateParseForm[ReqParamsT]()
+	var groups []*group
+	paramSources := []func(paramspopulator.ContextGetters, string) (ParamSource, bool){paramspopulator.GenerateSource[ReqParamsT]()}
+	var deprecatedFns []func(paramspopulator.ContextGetters) bool
+	if deprecated := paramspopulator.GenerateDeprecated[ReqParamsT](); deprecated != nil {
+		deprecatedFns = append(deprecatedFns, deprecated)
+	}
+	groupsRouteOption := func(r *fuego.BaseRoute) {
+		groups = takeRouteGroups(r)
+		for _, g := range groups {
+			paramSources = append(paramSources, g.source)
+			if g.deprecated != nil {
+				deprecatedFns = append(deprecatedFns, g.deprecated)
+			}
+		}
+	}
+	writeHeaders := func(http.ResponseWriter, any) error { return nil }
+	if resp, ok := any(new(RespBodyT)).(responseWithHeaders); ok {
+		writeHeaders = resp.headersWriter()
+	}
 	return func(c fuego.ContextWithBody[ReqBodyT]) (RespBodyT, error) {
-		req := &request[ReqParamsT, ReqBodyT]{ContextWithBody: c}
-		populateParams(c, &req.params)
-		return controller(req)
+		var zero RespBodyT
+		req := &request[ReqParamsT, ReqBodyT]{ContextWithBody: c, groupParams: make([]any, len(groups)), paramSources: paramSources}
+
+		if parseForm != nil {
+			if err := parseForm(c.Request()); err != nil {
+				return zero, paramsBadRequestError("cannot parse form body: ", err)
+			}
+		}
+
+		for _, deprecated := range deprecatedFns {
+			if deprecated(c) {
+				c.Response().Header().Set("Deprecation", "true")
+				break
+			}
+		}
+
+		// Population errors of the group and route params are reported together.
+		var paramErrs paramspopulator.ParamErrors
+		for i, g := range groups {
+			var err error
+			req.groupParams[i], err = g.populate(c)
+			paramErrs = appendParamErrors(paramErrs, err)
+		}
+		paramErrs = appendParamErrors(paramErrs, populateParams(c, &req.params))
+		if len(paramErrs) > 0 {
+			return zero, paramsBadRequestError("cannot parse request parameters: ", paramErrs)
+		}
+
+		for i, g := range groups {
+			if err := g.validate(c.Context(), req.groupParams[i]); err != nil {
+				return zero, paramsBadRequestError("invalid request parameters: ", err)
+			}
+		}
+		if err := validateParams(c.Context(), &req.params); err != nil {
+			return zero, paramsBadRequestError("invalid request parameters: ", err)
+		}
+		resp, err := controller(req)
+		if err != nil {
+			return resp, err
+		}
+		if err := writeHeaders(c.Response(), resp); err != nil {
+			return zero, fuego.InternalServerError{Err: err, Status: http.StatusInternalServerError}
+		}
+		if result, ok := any(resp).(statusResult); ok {
+			if err := setResultStatus(c.Response(), result); err != nil {
+				return zero, err
+			}
+		}
+		return resp, nil
+	}, groupsRouteOption
+}
+
+// appendParamErrors appends the ParamErrors returned by a params populator, if any, to errs.
+func appendParamErrors(errs paramspopulator.ParamErrors, err error) paramspopulator.ParamErrors {
+	var paramErrs paramspopulator.ParamErrors
+	if errors.As(err, &paramErrs) {
+		return append(errs, paramErrs...)
+	}
+	return errs
+}
+
+// paramsBadRequestError converts params population and validation errors to a fuego.BadRequestError (an RFC 9457
+// problem response) with one error item per invalid param. Errors that already have an HTTP status, e.g. a
+// fuego.HTTPError with status 422 returned by a Validate method, are returned as is.
+func paramsBadRequestError(detailPrefix string, err error) error {
+	var statusErr fuego.ErrorWithStatus
+	if errors.As(err, &statusErr) {
+		return err
+	}
+	var items []fuego.ErrorItem
+	var paramErrs paramspopulator.ParamErrors
+	if errors.As(err, &paramErrs) {
+		items = make([]fuego.ErrorItem, len(paramErrs))
+		for i, paramErr := range paramErrs {
+			items[i] = fuego.ErrorItem{Name: paramErr.Name, Reason: paramErr.Reason}
+			if in := paramErr.In.String(); in != "" {
+				items[i].More = map[string]any{"in": in}
+			}
+		}
+	}
+	return fuego.BadRequestError{
+		Title:  "Invalid Params",
+		Detail: detailPrefix + err.Error(),
+		Err:    err,
+		Errors: items,
 	}
 }
@@ -0,0 +1,512 @@
+package xfuego_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/go-fuego/fuego"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/crunk1/xfuego"
+	"github.com/crunk1/xfuego/paramsgen"
+)
+
+func newServer() *fuego.Server {
+	return fuego.NewServer(
+		fuego.WithoutLogger(),
+		fuego.WithoutStartupMessages(),
+		fuego.WithEngineOptions(fuego.WithOpenAPIConfig(fuego.OpenAPIConfig{DisableLocalSave: true, DisableSwaggerUI: true})),
+	)
+}
+
+// serve sends a request to the server, with the headers set on r by the optional setup function.
+func serve(s *fuego.Server, method string, target string, setup ...func(r *http.Request)) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, nil)
+	for _, fn := range setup {
+		fn(r)
+	}
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, r)
+	return w
+}
+
+// problem is an RFC 9457 problem response, as sent by fuego.
+type problem struct {
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+	Errors []struct {
+		Name   string         `json:"name"`
+		Reason string         `json:"reason"`
+		More   map[string]any `json:"more"`
+	} `json:"errors"`
+}
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) problem {
+	var p problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &p), w.Body.String())
+	return p
+}
+
+// paramErrors returns the "in name: reason" of each error item of a problem response.
+func (p problem) paramErrors() []string {
+	errs := make([]string, len(p.Errors))
+	for i, err := range p.Errors {
+		errs[i] = err.More["in"].(string) + " " + err.Name + ": " + err.Reason
+	}
+	return errs
+}
+
+func TestGet_missingRequired(t *testing.T) {
+	type Params struct {
+		Tags    []string `header:"X-Tags"`
+		Limit   int      `query:"limit"`
+		Session string   `cookie:"session"`
+		Sort    string   `query:"sort,,default=asc"`
+	}
+	a := assert.New(t)
+	s := newServer()
+	xfuego.Get(s, "/items", func(req xfuego.Request[Params, xfuego.None]) (string, error) {
+		return req.Params().Sort, nil
+	})
+
+	// Every missing param is reported by xfuego, in field order, not only the first one found by fuego.
+	w := serve(s, http.MethodGet, "/items")
+	a.Equal(http.StatusBadRequest, w.Code)
+	p := decodeProblem(t, w)
+	a.Equal("Invalid Params", p.Title)
+	a.Equal([]string{
+		"header X-Tags: required value is missing",
+		"query limit: required value is missing",
+		"cookie session: required value is missing",
+	}, p.paramErrors())
+
+	w = serve(s, http.MethodGet, "/items?limit=1", func(r *http.Request) {
+		r.Header.Set("X-Tags", "a")
+		r.AddCookie(&http.Cookie{Name: "session", Value: "s"})
+	})
+	a.Equal(http.StatusOK, w.Code)
+	a.Equal("asc", w.Body.String())
+
+	// Still documented as required
+	operation := s.OutputOpenAPISpec().Paths.Find("/items").Get
+	a.True(operation.Parameters.GetByInAndName("header", "X-Tags").Required)
+	a.True(operation.Parameters.GetByInAndName("query", "limit").Required)
+	a.False(operation.Parameters.GetByInAndName("query", "sort").Required)
+}
+
+func TestGet_aggregatedParamErrors(t *testing.T) {
+	type Params struct {
+		Tenant string      `header:"X-Tenant"`
+		Limit  int         `query:"limit,,default=20"`
+		Since  xfuego.Date `query:"since"`
+	}
+	a := assert.New(t)
+	s := newServer()
+	xfuego.Get(s, "/items", func(req xfuego.Request[Params, xfuego.None]) (string, error) {
+		return "", nil
+	})
+
+	w := serve(s, http.MethodGet, "/items?limit=ten&since=2025-01-01")
+	a.Equal(http.StatusBadRequest, w.Code)
+	a.Equal([]string{
+		"header X-Tenant: required value is missing",
+		`query limit: value is not a valid int: "ten"`,
+	}, decodeProblem(t, w).paramErrors())
+}
+
+// rangeParams checks its params with a Validate method.
+type rangeParams struct {
+	From int `query:"from"`
+	To   int `query:"to"`
+}
+
+func (p *rangeParams) Validate() error {
+	switch {
+	case p.From < 0:
+		return fuego.HTTPError{Status: http.StatusUnprocessableEntity, Detail: "from must not be negative"}
+	case p.From > p.To:
+		return xfuego.ParamError{Name: "to", Reason: "must not be before from"}
+	case p.To-p.From > 100:
+		return xfuego.ParamErrors{{Name: "from", Reason: "range is too wide"}, {Name: "to", Reason: "range is too wide"}}
+	case p.From == p.To:
+		return errors.New("range is empty")
+	}
+	return nil
+}
+
+// tenantParams checks its params with a context-aware Validate method.
+type tenantParams struct {
+	Tenant string `header:"X-Tenant"`
+}
+
+func (p *tenantParams) Validate(context.Context) error {
+	if p.Tenant == "blocked" {
+		return &xfuego.ParamError{Name: "X-Tenant", Reason: "tenant is blocked"}
+	}
+	return nil
+}
+
+func TestGet_validateParams(t *testing.T) {
+	s := newServer()
+	xfuego.Get(s, "/range", func(req xfuego.Request[rangeParams, xfuego.None]) (string, error) {
+		return "ok", nil
+	})
+	xfuego.Get(s, "/tenant", func(req xfuego.Request[tenantParams, xfuego.None]) (string, error) {
+		return "ok", nil
+	})
+
+	tests := []struct {
+		name            string
+		target          string
+		tenant          string
+		wantCode        int
+		wantDetail      string
+		wantParamErrors []string
+	}{
+		{"valid", "/range?from=1&to=2", "", http.StatusOK, "", nil},
+		{"ParamError", "/range?from=3&to=1", "", http.StatusBadRequest, "", []string{"query to: must not be before from"}},
+		{"ParamErrors", "/range?from=0&to=200", "", http.StatusBadRequest, "", []string{
+			"query from: range is too wide",
+			"query to: range is too wide",
+		}},
+		{"error with status", "/range?from=-1&to=0", "", http.StatusUnprocessableEntity, "from must not be negative", []string{}},
+		{"other error", "/range?from=1&to=1", "", http.StatusBadRequest, "invalid request parameters: range is empty", []string{}},
+		{"context valid", "/tenant", "acme", http.StatusOK, "", nil},
+		{"context ParamError", "/tenant", "blocked", http.StatusBadRequest, "", []string{"header X-Tenant: tenant is blocked"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			w := serve(s, http.MethodGet, tt.target, func(r *http.Request) { r.Header.Set("X-Tenant", tt.tenant) })
+			a.Equal(tt.wantCode, w.Code, w.Body.String())
+			if tt.wantCode == http.StatusOK {
+				a.Equal("ok", w.Body.String())
+				return
+			}
+			p := decodeProblem(t, w)
+			a.Equal(tt.wantCode, p.Status)
+			if tt.wantDetail != "" {
+				a.Equal(tt.wantDetail, p.Detail)
+			}
+			a.Equal(tt.wantParamErrors, p.paramErrors())
+		})
+	}
+}
+
+func TestGet_nestedParams(t *testing.T) {
+	type FilterParams struct {
+		Status string  `query:"status"`
+		Owner  *string `query:"owner"`
+	}
+	type Params struct {
+		Filter FilterParams `query:"filter"`
+	}
+	a := assert.New(t)
+	s := newServer()
+	xfuego.Get(s, "/items", func(req xfuego.Request[Params, xfuego.None]) (string, error) {
+		return req.Params().Filter.Status + " " + req.ParamSource("filter[owner]").String(), nil
+	})
+
+	// fuego logs a warning when reading a query param it does not know of, e.g. filter[status].
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	w := serve(s, http.MethodGet, "/items?filter[status]=open")
+	a.Equal(http.StatusOK, w.Code)
+	a.Equal("open missing", w.Body.String())
+	a.Empty(logs.String())
+}
+
+func TestGet_deprecationHeader(t *testing.T) {
+	type Params struct {
+		Limit  int     `query:"limit,,default=20,alias=page_size|per_page"`
+		Offset *int    `query:"offset,,deprecated"`
+		Tenant *string `header:"X-Tenant,,alias=X-Org"`
+	}
+	s := newServer()
+	xfuego.Get(s, "/items", func(req xfuego.Request[Params, xfuego.None]) (int, error) {
+		return req.Params().Limit, nil
+	})
+
+	tests := []struct {
+		name            string
+		target          string
+		header          string
+		wantBody        string
+		wantDeprecation string
+	}{
+		{"canonical names", "/items?limit=10", "X-Tenant", "10", ""},
+		{"no params", "/items", "", "20", ""},
+		{"query alias", "/items?page_size=10", "", "10", "true"},
+		{"second query alias", "/items?per_page=10", "", "10", "true"},
+		{"header alias", "/items", "X-Org", "20", "true"},
+		{"deprecated param", "/items?limit=10&offset=5", "", "10", "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			w := serve(s, http.MethodGet, tt.target, func(r *http.Request) {
+				if tt.header != "" {
+					r.Header.Set(tt.header, "acme")
+				}
+			})
+			a.Equal(http.StatusOK, w.Code, w.Body.String())
+			a.JSONEq(tt.wantBody, w.Body.String())
+			a.Equal(tt.wantDeprecation, w.Header().Get("Deprecation"))
+		})
+	}
+}
+
+func TestPost_formParams(t *testing.T) {
+	type Params struct {
+		Name  string    `form:"name,,minLength=1"`
+		Tags  *[]string `form:"tags,,style=pipeDelimited"`
+		Count int       `form:"count,,default=1"`
+		Dry   bool      `query:"dry,,default=false"`
+	}
+	s := newServer()
+	xfuego.Post(s, "/items", func(req xfuego.Request[Params, xfuego.None]) (Params, error) {
+		return req.Params(), nil
+	})
+
+	tests := []struct {
+		name            string
+		target          string
+		body            string
+		wantCode        int
+		wantBody        string
+		wantParamErrors []string
+	}{
+		{"valid", "/items?dry=true", "name=box&tags=a|b&count=3", http.StatusOK, `{"Name":"box","Tags":["a","b"],"Count":3,"Dry":true}`, nil},
+		{"defaults", "/items", "name=box", http.StatusOK, `{"Name":"box","Tags":null,"Count":1,"Dry":false}`, nil},
+		{"form values only", "/items?name=query", "", http.StatusBadRequest, "", []string{"form name: required value is missing"}},
+		{"invalid values", "/items", "name=&tags=a&count=many", http.StatusBadRequest, "", []string{
+			`form name: value must be at least 1 characters long: ""`,
+			`form count: value is not a valid int: "many"`,
+		}},
+		{"malformed body", "/items", "name=%zz", http.StatusBadRequest, "", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			r := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			s.Mux.ServeHTTP(w, r)
+			a.Equal(tt.wantCode, w.Code, w.Body.String())
+			if tt.wantCode == http.StatusOK {
+				a.JSONEq(tt.wantBody, w.Body.String())
+				return
+			}
+			a.Equal(tt.wantParamErrors, decodeProblem(t, w).paramErrors())
+		})
+	}
+}
+
+func TestTryPost_formParamsWithBody(t *testing.T) {
+	type Params struct {
+		Name string `form:"name"`
+	}
+	type Body struct {
+		Name string `json:"name"`
+	}
+	a := assert.New(t)
+	s := newServer()
+	_, err := xfuego.TryPost(s, "/items", func(req xfuego.Request[Params, Body]) (string, error) {
+		return "", nil
+	})
+	var structErr *xfuego.ParamsStructError
+	a.True(errors.As(err, &structErr), err)
+	a.Equal([]string{"form params require the None request body type"}, structErr.Errors)
+}
+
+func TestHandle(t *testing.T) {
+	a := assert.New(t)
+	s := newServer()
+	controller := func(req xfuego.Request[listParams, xfuego.None]) (string, error) {
+		return req.Request().Method, nil
+	}
+	xfuego.Handle(s, http.MethodHead, "/head", controller)
+	xfuego.Handle(s, "PURGE", "/purge", controller)
+	xfuego.Handle(s, "", "/all", controller)
+	xfuego.All(s, "/fuego-all", controller)
+
+	tests := []struct {
+		method   string
+		target   string
+		wantCode int
+	}{
+		{http.MethodHead, "/head", http.StatusOK},
+		{http.MethodGet, "/head", http.StatusMethodNotAllowed},
+		{"PURGE", "/purge", http.StatusOK},
+		{http.MethodGet, "/purge", http.StatusMethodNotAllowed},
+		{http.MethodDelete, "/purge", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/all", http.StatusOK},
+		{http.MethodPost, "/all", http.StatusOK},
+		{"PURGE", "/all", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			w := serve(s, tt.method, tt.target)
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusOK && tt.method != http.MethodHead {
+				assert.Equal(t, tt.method, w.Body.String())
+			}
+		})
+	}
+
+	// Params are populated as for the other methods
+	w := serve(s, "PURGE", "/purge?limit=ten")
+	a.Equal(http.StatusBadRequest, w.Code)
+	a.Equal([]string{`query limit: value is not a valid int: "ten"`}, decodeProblem(t, w).paramErrors())
+
+	spec := s.OutputOpenAPISpec()
+	a.NotNil(spec.Paths.Find("/head").Head)
+	a.NotNil(spec.Paths.Find("/head").Head.Parameters.GetByInAndName("query", "limit"))
+	a.Nil(spec.Paths.Find("/purge"), "custom methods are hidden")
+	a.Nil(spec.Paths.Find("/fuego-all"))
+	a.Nil(spec.Paths.Find("/all"), `"" is not documented, as with All`)
+}
+
+// failingText is a text type whose MarshalText method fails.
+type failingText struct{}
+
+func (failingText) MarshalText() ([]byte, error) {
+	return nil, errors.New("marshal failed")
+}
+
+func (*failingText) UnmarshalText([]byte) error {
+	return nil
+}
+
+func TestGet_response(t *testing.T) {
+	type Headers struct {
+		RateLimit int     `header:"X-Rate-Limit,Requests left"`
+		Session   string  `cookie:"session"`
+		Theme     *string `cookie:"theme"`
+	}
+	type FailingHeaders struct {
+		RateLimit int         `header:"X-Rate-Limit"`
+		Failing   failingText `header:"X-Failing"`
+	}
+	type Body struct {
+		Name string `json:"name"`
+	}
+	a := assert.New(t)
+	s := newServer()
+	xfuego.Get(s, "/items", func(req xfuego.Request[xfuego.None, xfuego.None]) (xfuego.Response[Headers, Body], error) {
+		return xfuego.Response[Headers, Body]{Headers: Headers{RateLimit: 9, Session: "abc"}, Body: Body{Name: "a"}}, nil
+	})
+	xfuego.Get(s, "/failing", func(req xfuego.Request[xfuego.None, xfuego.None]) (xfuego.Response[FailingHeaders, Body], error) {
+		return xfuego.Response[FailingHeaders, Body]{Headers: FailingHeaders{RateLimit: 9}}, nil
+	})
+
+	w := serve(s, http.MethodGet, "/items")
+	a.Equal(http.StatusOK, w.Code)
+	a.Equal("9", w.Header().Get("X-Rate-Limit"))
+	a.Equal([]string{"session=abc"}, w.Header().Values("Set-Cookie"), "nil optional cookies are not written")
+	a.JSONEq(`{"name": "a"}`, w.Body.String())
+
+	// A header value that cannot be formatted is an internal error, and no header is written
+	w = serve(s, http.MethodGet, "/failing")
+	a.Equal(http.StatusInternalServerError, w.Code)
+	a.Empty(w.Header().Get("X-Rate-Limit"))
+
+	response := s.OutputOpenAPISpec().Paths.Find("/items").Get.Responses.Status(http.StatusOK).Value
+	a.Equal("Requests left", response.Headers["X-Rate-Limit"].Value.Description)
+	a.Equal("Sets the cookies: session, theme.", response.Headers["Set-Cookie"].Value.Description)
+	a.NotNil(response.Content.Get("application/json"))
+}
+
+func TestTryGet_generatedOutOfDate(t *testing.T) {
+	type Params struct {
+		Limit int `query:"limit,,default=1"`
+	}
+	a := assert.New(t)
+	s := newServer()
+	paramsgen.Register(func(paramsgen.Getters, *Params) error { return nil }, nil, `Limit int query:"limit"`)
+
+	_, err := xfuego.TryGet(s, "/items", func(req xfuego.Request[Params, xfuego.None]) (string, error) {
+		return "", nil
+	})
+	var structErr *xfuego.ParamsStructError
+	a.True(errors.As(err, &structErr), err)
+	a.Equal([]string{"the code generated by xfuego-gen is out of date, run go generate"}, structErr.Errors)
+
+	_, err = xfuego.TryGroup[Params](s, "/group")
+	a.True(errors.As(err, &structErr), err)
+}
+
+func TestGet_generatedParams(t *testing.T) {
+	type Params struct {
+		Limit int      `query:"limit,Max items,default=20,min=1,example=small=5"`
+		Tags  []string `header:"X-Tags,,pattern=^[a-z]+$"`
+		Ratio *float32 `query:"ratio,,enum=0.5|1.5"`
+	}
+	type GenParams Params
+	a := assert.New(t)
+	tagsPattern := regexp.MustCompile("^[a-z]+$")
+	paramsgen.Register(func(c paramsgen.Getters, params *GenParams) error {
+		params.Limit = 7
+		return nil
+	}, []paramsgen.Param{
+		{
+			Field:    "Limit",
+			In:       paramsgen.InQuery,
+			Name:     "limit",
+			Desc:     "Max items",
+			Type:     reflect.TypeFor[int](),
+			Default:  int(20),
+			Examples: map[string]any{"small": int(5)},
+			Min:      paramsgen.Ptr[float64](1),
+		},
+		{
+			Field:    "Tags",
+			In:       paramsgen.InHeader,
+			Name:     "X-Tags",
+			Type:     reflect.TypeFor[string](),
+			Slice:    true,
+			Required: true,
+			Style:    "simple",
+			Explode:  paramsgen.Ptr(false),
+			Pattern:  tagsPattern,
+		},
+		{
+			Field: "Ratio",
+			In:    paramsgen.InQuery,
+			Name:  "ratio",
+			Type:  reflect.TypeFor[float32](),
+			Enum:  []any{float32(0.5), float32(1.5)},
+		},
+	}, `Limit int query:"limit,Max items,default=20,min=1,example=small=5"; Tags []string header:"X-Tags,,pattern=^[a-z]+$"; Ratio *float32 query:"ratio,,enum=0.5|1.5"`)
+	s := newServer()
+	xfuego.Get(s, "/parsed", func(req xfuego.Request[Params, xfuego.None]) (int, error) {
+		return req.Params().Limit, nil
+	})
+	xfuego.Get(s, "/generated", func(req xfuego.Request[GenParams, xfuego.None]) (int, error) {
+		return req.Params().Limit, nil
+	})
+
+	// The generated params are documented as the parsed ones
+	spec := s.OutputOpenAPISpec()
+	a.NotNil(spec.Paths.Find("/generated").Get.Parameters.GetByInAndName("query", "ratio"))
+	parsed, err := json.Marshal(spec.Paths.Find("/parsed").Get.Parameters)
+	a.NoError(err)
+	generated, err := json.Marshal(spec.Paths.Find("/generated").Get.Parameters)
+	a.NoError(err)
+	a.JSONEq(string(parsed), string(generated))
+
+	w := serve(s, http.MethodGet, "/generated", func(r *http.Request) { r.Header.Set("X-Tags", "a") })
+	a.Equal(http.StatusOK, w.Code)
+	a.JSONEq("7", w.Body.String())
+}